@@ -0,0 +1,112 @@
+package taints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// AlertRule maps an alert to the taints of the node it fires for.
+type AlertRule struct {
+	// Alert is the alertname label of the alert.
+	Alert string `json:"alert"`
+	// Taints are the specs of the taints added while the alert fires and removed once it
+	// resolves.
+	Taints []string `json:"taints"`
+}
+
+// ApplyFunc applies taint changes to a node.
+type ApplyFunc func(ctx context.Context, nodeName string, taints, taintsToRemove []v1.Taint) error
+
+// alertmanagerPayload is the part of the Alertmanager webhook payload used by AlertReceiver.
+type alertmanagerPayload struct {
+	Alerts []struct {
+		Status string            `json:"status"`
+		Labels map[string]string `json:"labels"`
+	} `json:"alerts"`
+}
+
+// AlertReceiver is an http.Handler receiving Alertmanager webhook notifications and tainting the
+// nodes that alerts fire for according to its rules.
+type AlertReceiver struct {
+	rules     map[string][]v1.Taint
+	nodeLabel string
+	apply     ApplyFunc
+}
+
+// NewAlertReceiver returns an AlertReceiver applying the rules through apply. The node an alert
+// fires for is read from the alert label nodeLabel, and alerts without it are ignored. It is an
+// error for a taint to be used by rules of different alerts, since resolving one alert would then
+// remove the taint while the other still fires.
+func NewAlertReceiver(rules []AlertRule, nodeLabel string, apply ApplyFunc) (*AlertReceiver, error) {
+	receiver := &AlertReceiver{
+		rules:     map[string][]v1.Taint{},
+		nodeLabel: nodeLabel,
+		apply:     apply,
+	}
+	owners := map[v1.Taint]string{}
+	for _, rule := range rules {
+		if _, ok := receiver.rules[rule.Alert]; ok {
+			return nil, fmt.Errorf("invalid rule for alert %v: alert has more than one rule", rule.Alert)
+		}
+		taints, taintsToRemove, err := ParseTaints(rule.Taints)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule for alert %v: %v", rule.Alert, err)
+		}
+		if len(taintsToRemove) > 0 {
+			return nil, fmt.Errorf("invalid rule for alert %v: rules may only add taints", rule.Alert)
+		}
+		for _, taint := range taints {
+			id := v1.Taint{Key: taint.Key, Effect: taint.Effect}
+			if owner, ok := owners[id]; ok {
+				return nil, fmt.Errorf("invalid rule for alert %v: taint %v is already managed for alert %v", rule.Alert, id.ToString(), owner)
+			}
+			owners[id] = rule.Alert
+		}
+		receiver.rules[rule.Alert] = taints
+	}
+	return receiver, nil
+}
+
+// ServeHTTP handles a webhook notification. Firing alerts add the taints of their rule to their
+// node and resolved alerts remove them. It responds with 500 if applying any change fails, so that
+// Alertmanager retries the notification.
+func (r *AlertReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var payload alertmanagerPayload
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		http.Error(w, fmt.Sprintf("invalid notification: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, alert := range payload.Alerts {
+		taints, ok := r.rules[alert.Labels["alertname"]]
+		nodeName := alert.Labels[r.nodeLabel]
+		if !ok || len(nodeName) == 0 {
+			continue
+		}
+
+		var err error
+		switch alert.Status {
+		case "firing":
+			err = r.apply(req.Context(), nodeName, taints, nil)
+		case "resolved":
+			taintsToRemove := make([]v1.Taint, 0, len(taints))
+			for _, taint := range taints {
+				taintsToRemove = append(taintsToRemove, v1.Taint{Key: taint.Key, Effect: taint.Effect})
+			}
+			err = r.apply(req.Context(), nodeName, nil, taintsToRemove)
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("applying taints to node %v: %v", nodeName, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
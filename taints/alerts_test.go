@@ -0,0 +1,139 @@
+package taints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNewAlertReceiver(t *testing.T) {
+	cases := []struct {
+		name        string
+		rules       []AlertRule
+		expectedErr bool
+	}{
+		{
+			name:  "valid rules",
+			rules: []AlertRule{{Alert: "DiskErrors", Taints: []string{"example.com/disk-errors:NoSchedule"}}},
+		},
+		{
+			name:        "invalid spec",
+			rules:       []AlertRule{{Alert: "DiskErrors", Taints: []string{"example.com/disk-errors"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "removal spec",
+			rules:       []AlertRule{{Alert: "DiskErrors", Taints: []string{"example.com/disk-errors-"}}},
+			expectedErr: true,
+		},
+		{
+			name: "alert with two rules",
+			rules: []AlertRule{
+				{Alert: "DiskErrors", Taints: []string{"a:NoSchedule"}},
+				{Alert: "DiskErrors", Taints: []string{"b:NoSchedule"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "taint managed by two alerts",
+			rules: []AlertRule{
+				{Alert: "DiskErrors", Taints: []string{"m:NoSchedule"}},
+				{Alert: "MemoryErrors", Taints: []string{"m=memory:NoSchedule"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "taints with different effects",
+			rules: []AlertRule{
+				{Alert: "DiskErrors", Taints: []string{"m:NoSchedule"}},
+				{Alert: "MemoryErrors", Taints: []string{"m:NoExecute"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		_, err := NewAlertReceiver(c.rules, "node", nil)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+	}
+}
+
+func TestAlertReceiver(t *testing.T) {
+	var applied []string
+	var applyErr error
+	receiver, err := NewAlertReceiver([]AlertRule{
+		{Alert: "DiskErrors", Taints: []string{"example.com/disk-errors=high:NoSchedule"}},
+	}, "instance", func(_ context.Context, nodeName string, taints, taintsToRemove []v1.Taint) error {
+		applied = append(applied, fmt.Sprintf("%s %v %v", nodeName, taints, taintsToRemove))
+		return applyErr
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	cases := []struct {
+		name            string
+		method          string
+		body            string
+		applyErr        error
+		expectedStatus  int
+		expectedApplied []string
+	}{
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "malformed notification",
+			method:         http.MethodPost,
+			body:           "{",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "firing and resolved alerts",
+			method: http.MethodPost,
+			body: `{"alerts":[
+				{"status":"firing","labels":{"alertname":"DiskErrors","instance":"worker-1"}},
+				{"status":"resolved","labels":{"alertname":"DiskErrors","instance":"worker-2"}},
+				{"status":"firing","labels":{"alertname":"DiskErrors"}},
+				{"status":"firing","labels":{"alertname":"HighLoad","instance":"worker-3"}}
+			]}`,
+			expectedStatus: http.StatusOK,
+			expectedApplied: []string{
+				fmt.Sprintf("worker-1 %v %v", []v1.Taint{{Key: "example.com/disk-errors", Value: "high", Effect: v1.TaintEffectNoSchedule}}, []v1.Taint(nil)),
+				fmt.Sprintf("worker-2 %v %v", []v1.Taint(nil), []v1.Taint{{Key: "example.com/disk-errors", Effect: v1.TaintEffectNoSchedule}}),
+			},
+		},
+		{
+			name:            "failing apply",
+			method:          http.MethodPost,
+			body:            `{"alerts":[{"status":"firing","labels":{"alertname":"DiskErrors","instance":"worker-1"}}]}`,
+			applyErr:        fmt.Errorf("conflict"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedApplied: []string{fmt.Sprintf("worker-1 %v %v", []v1.Taint{{Key: "example.com/disk-errors", Value: "high", Effect: v1.TaintEffectNoSchedule}}, []v1.Taint(nil))},
+		},
+	}
+
+	for _, c := range cases {
+		applied, applyErr = nil, c.applyErr
+		recorder := httptest.NewRecorder()
+		receiver.ServeHTTP(recorder, httptest.NewRequest(c.method, "/", strings.NewReader(c.body)))
+		if recorder.Code != c.expectedStatus {
+			t.Errorf("[%s] expected status %d, but got: %d", c.name, c.expectedStatus, recorder.Code)
+		}
+		if !reflect.DeepEqual(c.expectedApplied, applied) {
+			t.Errorf("[%s] expected applied changes %v, but got: %v", c.name, c.expectedApplied, applied)
+		}
+	}
+}
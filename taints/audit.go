@@ -0,0 +1,165 @@
+package taints
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// auditEvent holds the fields of a Kubernetes audit event that describe node changes.
+type auditEvent struct {
+	AuditID string `json:"auditID"`
+	Stage   string `json:"stage"`
+	Verb    string `json:"verb"`
+	User    struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Name        string `json:"name"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+	RequestObject  json.RawMessage `json:"requestObject"`
+	ResponseObject json.RawMessage `json:"responseObject"`
+	StageTimestamp time.Time       `json:"stageTimestamp"`
+}
+
+// auditNodeTaints holds the taints of a node, or of a patch to one, in an audit event.
+type auditNodeTaints struct {
+	Spec struct {
+		Taints *[]v1.Taint `json:"taints"`
+	} `json:"spec"`
+}
+
+// TaintMutation is a change of the taints of a node recorded by the audit log.
+type TaintMutation struct {
+	Time    time.Time `json:"time"`
+	AuditID string    `json:"auditID"`
+	User    string    `json:"user"`
+	Verb    string    `json:"verb"`
+	Node    string    `json:"node"`
+	// Taints are the taints of the node after the change.
+	Taints []v1.Taint `json:"taints"`
+	// Diff holds the changes from the taints of the previous mutation of the node.
+	Diff TaintDiff `json:"diff"`
+	// Initial is set for the first event of a node in the log. Unless the event creates the node,
+	// the previous taints are unknown and Diff is relative to no taints.
+	Initial bool `json:"initial,omitempty"`
+}
+
+// ReadTaintMutations reads Kubernetes audit events, one JSON object per line, and returns the
+// successful creates, updates and patches of nodes that change their taints, in log order. Taints
+// are read from the response object of the event, or from its request object if the response
+// was not logged, which requires the RequestResponse or Request audit level for nodes. Events
+// without taints, such as JSON patches logged at the Request level, are skipped.
+func ReadTaintMutations(r io.Reader) ([]TaintMutation, error) {
+	var mutations []TaintMutation
+	known := map[string][]v1.Taint{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event auditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("invalid audit event on line %d: %v", line, err)
+		}
+		if !event.changesNode() {
+			continue
+		}
+		taints, ok := event.taints()
+		if !ok {
+			continue
+		}
+
+		previous, seen := known[event.ObjectRef.Name]
+		known[event.ObjectRef.Name] = taints
+		diff := DiffTaints(previous, taints)
+		if seen && diff.IsEmpty() {
+			continue
+		}
+		mutations = append(mutations, TaintMutation{
+			Time:    event.StageTimestamp,
+			AuditID: event.AuditID,
+			User:    event.User.Username,
+			Verb:    event.Verb,
+			Node:    event.ObjectRef.Name,
+			Taints:  taints,
+			Diff:    diff,
+			Initial: !seen,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mutations, nil
+}
+
+// changesNode reports whether the event is a completed, successful write to a node.
+func (e *auditEvent) changesNode() bool {
+	if e.Stage != "ResponseComplete" || e.ObjectRef == nil || e.ObjectRef.Resource != "nodes" || len(e.ObjectRef.Subresource) > 0 {
+		return false
+	}
+	if e.ResponseStatus == nil || e.ResponseStatus.Code >= 300 {
+		return false
+	}
+	switch e.Verb {
+	case "create", "update", "patch":
+		return true
+	}
+	return false
+}
+
+// taints returns the taints of the node after the event, if it records them.
+func (e *auditEvent) taints() ([]v1.Taint, bool) {
+	var node auditNodeTaints
+	if len(e.ResponseObject) > 0 && json.Unmarshal(e.ResponseObject, &node) == nil {
+		// the response holds the whole node, which has no taints if it lists none
+		if node.Spec.Taints == nil {
+			return nil, true
+		}
+		return *node.Spec.Taints, true
+	}
+	if len(e.RequestObject) == 0 || json.Unmarshal(e.RequestObject, &node) != nil {
+		return nil, false
+	}
+	if node.Spec.Taints == nil {
+		// a patch not listing taints leaves them unchanged
+		return nil, e.Verb != "patch"
+	}
+	return *node.Spec.Taints, true
+}
+
+// AuditReport returns a report with a row per taint mutation, answering who changed which taints
+// of which node and when.
+func AuditReport(mutations []TaintMutation) Report {
+	report := Report{
+		Title:   "Taint changes",
+		Columns: []string{"Time", "User", "Node", "Added", "Changed", "Removed"},
+	}
+	for _, mutation := range mutations {
+		changed := make([]string, 0, len(mutation.Diff.Changed))
+		for _, change := range mutation.Diff.Changed {
+			changed = append(changed, defaultFormatter.Format(change.From)+" → "+defaultFormatter.Format(change.To))
+		}
+		report.Rows = append(report.Rows, []string{
+			mutation.Time.UTC().Format(time.RFC3339),
+			mutation.User,
+			mutation.Node,
+			formatTaintList(mutation.Diff.Added),
+			strings.Join(changed, ", "),
+			formatTaintList(mutation.Diff.Removed),
+		})
+	}
+	return report
+}
@@ -0,0 +1,67 @@
+package taints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const testAuditLog = `{"auditID":"1","stage":"ResponseComplete","verb":"create","user":{"username":"system:node:worker-1"},"objectRef":{"resource":"nodes","name":"worker-1"},"responseStatus":{"code":201},"requestObject":{"kind":"Node","metadata":{"name":"worker-1"},"spec":{"taints":[{"key":"node.example.com/uninitialized","effect":"NoSchedule"}]}},"stageTimestamp":"2024-01-01T10:00:00Z"}
+{"auditID":"2","stage":"ResponseComplete","verb":"patch","user":{"username":"system:node:worker-1"},"objectRef":{"resource":"nodes","name":"worker-1","subresource":"status"},"responseStatus":{"code":200},"requestObject":{"status":{}},"stageTimestamp":"2024-01-01T10:01:00Z"}
+{"auditID":"3","stage":"ResponseComplete","verb":"patch","user":{"username":"alice"},"objectRef":{"resource":"nodes","name":"worker-1"},"responseStatus":{"code":200},"requestObject":{"spec":{"taints":[{"key":"example.com/maintenance","effect":"NoExecute"}]}},"stageTimestamp":"2024-01-01T11:00:00Z"}
+{"auditID":"4","stage":"ResponseComplete","verb":"patch","user":{"username":"bob"},"objectRef":{"resource":"nodes","name":"worker-1"},"responseStatus":{"code":200},"requestObject":{"metadata":{"labels":{"pool":"gpu"}}},"stageTimestamp":"2024-01-01T11:30:00Z"}
+{"auditID":"5","stage":"ResponseComplete","verb":"patch","user":{"username":"mallory"},"objectRef":{"resource":"nodes","name":"worker-1"},"responseStatus":{"code":403},"requestObject":{"spec":{"taints":[]}},"stageTimestamp":"2024-01-01T11:45:00Z"}
+
+{"auditID":"6","stage":"ResponseComplete","verb":"update","user":{"username":"bob"},"objectRef":{"resource":"nodes","name":"worker-1"},"responseStatus":{"code":200},"responseObject":{"kind":"Node","metadata":{"name":"worker-1"},"spec":{}},"stageTimestamp":"2024-01-01T12:00:00Z"}
+`
+
+func TestReadTaintMutations(t *testing.T) {
+	mutations, err := ReadTaintMutations(strings.NewReader(testAuditLog))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	uninitialized := v1.Taint{Key: "node.example.com/uninitialized", Effect: v1.TaintEffectNoSchedule}
+	maintenance := v1.Taint{Key: "example.com/maintenance", Effect: v1.TaintEffectNoExecute}
+	expected := []struct {
+		auditID string
+		user    string
+		added   []v1.Taint
+		removed []v1.Taint
+		initial bool
+	}{
+		{auditID: "1", user: "system:node:worker-1", added: []v1.Taint{uninitialized}, initial: true},
+		{auditID: "3", user: "alice", added: []v1.Taint{maintenance}, removed: []v1.Taint{uninitialized}},
+		{auditID: "6", user: "bob", removed: []v1.Taint{maintenance}},
+	}
+	if len(mutations) != len(expected) {
+		t.Fatalf("expected %d mutations, but got: %+v", len(expected), mutations)
+	}
+	for i, e := range expected {
+		m := mutations[i]
+		if m.AuditID != e.auditID || m.User != e.user || m.Node != "worker-1" || m.Initial != e.initial {
+			t.Errorf("expected mutation %d to be event %v by %v, but got: %+v", i, e.auditID, e.user, m)
+		}
+		if !reflect.DeepEqual(e.added, m.Diff.Added) || !reflect.DeepEqual(e.removed, m.Diff.Removed) {
+			t.Errorf("expected mutation %d to add %v and remove %v, but got: %+v", i, e.added, e.removed, m.Diff)
+		}
+	}
+
+	report := AuditReport(mutations)
+	expectedRow := []string{"2024-01-01T11:00:00Z", "alice", "worker-1", "example.com/maintenance:NoExecute", "", "node.example.com/uninitialized:NoSchedule"}
+	if !reflect.DeepEqual(expectedRow, report.Rows[1]) {
+		t.Errorf("expected report row %v, but got: %v", expectedRow, report.Rows[1])
+	}
+	if !mutations[0].Time.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the time of the stage, but got: %v", mutations[0].Time)
+	}
+}
+
+func TestReadTaintMutationsInvalid(t *testing.T) {
+	if _, err := ReadTaintMutations(strings.NewReader("{\"auditID\":")); err == nil {
+		t.Errorf("expected error for an invalid event, but got nothing")
+	}
+}
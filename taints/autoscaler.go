@@ -0,0 +1,173 @@
+package taints
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// autoscalerToBeDeletedTaint is added by cluster-autoscaler to nodes it is removing.
+	autoscalerToBeDeletedTaint = "ToBeDeletedByClusterAutoscaler"
+	// autoscalerDeletionCandidateTaint is added by cluster-autoscaler to nodes it may remove.
+	autoscalerDeletionCandidateTaint = "DeletionCandidateOfClusterAutoscaler"
+
+	// Key prefixes marking taints that cluster-autoscaler ignores without configuration.
+	autoscalerIgnoreTaintPrefix  = "ignore-taint.cluster-autoscaler.kubernetes.io/"
+	autoscalerStartupTaintPrefix = "startup-taint.cluster-autoscaler.kubernetes.io/"
+	autoscalerStatusTaintPrefix  = "status-taint.cluster-autoscaler.kubernetes.io/"
+
+	// autoscalerTemplateTaintTagPrefix prefixes the cloud provider tags, such as those of AWS
+	// auto scaling groups, holding the template taints of node groups scaled from zero, with
+	// values of the form '<value>:<effect>'.
+	autoscalerTemplateTaintTagPrefix = "k8s.io/cluster-autoscaler/node-template/taint/"
+	// autoscalerTemplateTaintsAnnotation is the Cluster API annotation holding the template taints
+	// of node groups scaled from zero, as comma separated specs.
+	autoscalerTemplateTaintsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/taints"
+)
+
+// AutoscalerTaintConfig holds the taint keys cluster-autoscaler is configured to ignore when
+// comparing nodes with the templates of their node groups.
+type AutoscalerTaintConfig struct {
+	// IgnoreTaints are the keys given with --ignore-taint.
+	IgnoreTaints []string `json:"ignoreTaints,omitempty"`
+	// StartupTaints are the keys given with --startup-taint.
+	StartupTaints []string `json:"startupTaints,omitempty"`
+	// StatusTaints are the keys given with --status-taint.
+	StatusTaints []string `json:"statusTaints,omitempty"`
+}
+
+// autoscalerFlag is a cluster-autoscaler flag and the keys of the config it holds.
+type autoscalerFlag struct {
+	name string
+	keys *[]string
+}
+
+func (c *AutoscalerTaintConfig) autoscalerFlags() []autoscalerFlag {
+	return []autoscalerFlag{
+		{"--ignore-taint", &c.IgnoreTaints},
+		{"--startup-taint", &c.StartupTaints},
+		{"--status-taint", &c.StatusTaints},
+	}
+}
+
+// ParseAutoscalerTaintFlags reads the taint flags from cluster-autoscaler command line
+// arguments, in either the `--flag=key` or `--flag key` form. Other arguments are ignored. Every
+// key must be a valid taint key.
+func ParseAutoscalerTaintFlags(args []string) (AutoscalerTaintConfig, error) {
+	var config AutoscalerTaintConfig
+	flags := config.autoscalerFlags()
+	for i := 0; i < len(args); i++ {
+		for _, flag := range flags {
+			var key string
+			switch {
+			case strings.HasPrefix(args[i], flag.name+"="):
+				key = strings.TrimPrefix(args[i], flag.name+"=")
+			case args[i] == flag.name && i+1 < len(args):
+				i++
+				key = args[i]
+			case args[i] == flag.name:
+				return AutoscalerTaintConfig{}, fmt.Errorf("flag %v needs a taint key", flag.name)
+			default:
+				continue
+			}
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return AutoscalerTaintConfig{}, fmt.Errorf("invalid taint key for %v: %v, %s", flag.name, key, strings.Join(errs, "; "))
+			}
+			*flag.keys = append(*flag.keys, key)
+			break
+		}
+	}
+	return config, nil
+}
+
+// Flags returns the config as cluster-autoscaler command line arguments.
+func (c AutoscalerTaintConfig) Flags() []string {
+	var args []string
+	for _, flag := range c.autoscalerFlags() {
+		for _, key := range *flag.keys {
+			args = append(args, flag.name+"="+key)
+		}
+	}
+	return args
+}
+
+// IgnoreForTemplate reports whether cluster-autoscaler disregards the taint when comparing a
+// node with the template of its node group, as it does for its own taints, taints with one of
+// its ignore, startup or status key prefixes, lifecycle taints from the well-known catalog, and
+// the configured keys.
+func (c AutoscalerTaintConfig) IgnoreForTemplate(taint v1.Taint) bool {
+	if strings.HasPrefix(taint.Key, autoscalerIgnoreTaintPrefix) ||
+		strings.HasPrefix(taint.Key, autoscalerStartupTaintPrefix) ||
+		strings.HasPrefix(taint.Key, autoscalerStatusTaintPrefix) {
+		return true
+	}
+	if wellKnown, ok := LookupWellKnownTaint(taint.Key); ok && wellKnown.Lifecycle {
+		return true
+	}
+	for _, flag := range c.autoscalerFlags() {
+		for _, key := range *flag.keys {
+			if key == taint.Key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TemplateTaints returns the taints that cluster-autoscaler compares with node group templates,
+// i.e. those not ignored by IgnoreForTemplate.
+func (c AutoscalerTaintConfig) TemplateTaints(taints []v1.Taint) []v1.Taint {
+	var kept []v1.Taint
+	for _, taint := range taints {
+		if !c.IgnoreForTemplate(taint) {
+			kept = append(kept, taint)
+		}
+	}
+	return kept
+}
+
+// ParseAutoscalerTemplateTaints reads the taints of a node group template from the tags or
+// annotations cluster-autoscaler reads them from when scaling the group from zero: cloud
+// provider tags such as 'k8s.io/cluster-autoscaler/node-template/taint/dedicated: gpu:NoSchedule',
+// and the 'capacity.cluster-autoscaler.kubernetes.io/taints' annotation of Cluster API resources.
+// Other tags and annotations are ignored.
+func ParseAutoscalerTemplateTaints(tags map[string]string) ([]v1.Taint, error) {
+	var spec []string
+	for tag, value := range tags {
+		switch {
+		case strings.HasPrefix(tag, autoscalerTemplateTaintTagPrefix):
+			spec = append(spec, strings.TrimPrefix(tag, autoscalerTemplateTaintTagPrefix)+"="+value)
+		case tag == autoscalerTemplateTaintsAnnotation:
+			spec = append(spec, splitSpecList(value)...)
+		}
+	}
+	// map iteration order is random
+	sort.Strings(spec)
+	taints, taintsToRemove, err := ParseTaints(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node template taints: %v", err)
+	}
+	if len(taintsToRemove) > 0 {
+		return nil, fmt.Errorf("invalid node template taints: taints must not be removed")
+	}
+	return taints, nil
+}
+
+// SimulateScaleUp predicts for each pending pod whether adding a node from a node group template
+// with the taints would let it schedule, as far as taints are concerned. Taints ignored by
+// IgnoreForTemplate are left out, and only NoSchedule and NoExecute taints keep pods away. Pods
+// are named '<namespace>/<name>'.
+func (c AutoscalerTaintConfig) SimulateScaleUp(template []v1.Taint, pods []v1.Pod) []WorkloadCheck {
+	workloads := make([]Workload, 0, len(pods))
+	for i := range pods {
+		workloads = append(workloads, Workload{
+			Name:        pods[i].Namespace + "/" + pods[i].Name,
+			Tolerations: pods[i].Spec.Tolerations,
+		})
+	}
+	return CheckWorkloads(workloads, c.TemplateTaints(template))
+}
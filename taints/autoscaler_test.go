@@ -0,0 +1,156 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseAutoscalerTaintFlags(t *testing.T) {
+	cases := []struct {
+		name           string
+		args           []string
+		expectedConfig AutoscalerTaintConfig
+		expectedFlags  []string
+		expectedErr    bool
+	}{
+		{
+			name: "no taint flags",
+			args: []string{"--cloud-provider=aws", "--v", "4"},
+		},
+		{
+			name: "both flag forms",
+			args: []string{"--ignore-taint=example.com/a", "--startup-taint", "example.com/b", "--v=4", "--status-taint=example.com/c", "--ignore-taint", "example.com/d"},
+			expectedConfig: AutoscalerTaintConfig{
+				IgnoreTaints:  []string{"example.com/a", "example.com/d"},
+				StartupTaints: []string{"example.com/b"},
+				StatusTaints:  []string{"example.com/c"},
+			},
+			expectedFlags: []string{"--ignore-taint=example.com/a", "--ignore-taint=example.com/d", "--startup-taint=example.com/b", "--status-taint=example.com/c"},
+		},
+		{
+			name:        "missing key",
+			args:        []string{"--ignore-taint"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid key",
+			args:        []string{"--ignore-taint=not a key"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		config, err := ParseAutoscalerTaintFlags(c.args)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for args %v, but got nothing", c.name, c.args)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for args %v, but got: %v", c.name, c.args, err)
+		}
+		if !reflect.DeepEqual(c.expectedConfig, config) {
+			t.Errorf("[%s] expected config %+v, but got: %+v", c.name, c.expectedConfig, config)
+		}
+		if flags := config.Flags(); !reflect.DeepEqual(c.expectedFlags, flags) {
+			t.Errorf("[%s] expected flags %v, but got: %v", c.name, c.expectedFlags, flags)
+		}
+	}
+}
+
+func TestTemplateTaints(t *testing.T) {
+	config := AutoscalerTaintConfig{IgnoreTaints: []string{"example.com/ignored"}}
+	taints := []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "example.com/ignored", Effect: v1.TaintEffectNoSchedule},
+		{Key: "startup-taint.cluster-autoscaler.kubernetes.io/init", Effect: v1.TaintEffectNoSchedule},
+		{Key: v1.TaintNodeNotReady, Effect: v1.TaintEffectNoExecute},
+		{Key: "ToBeDeletedByClusterAutoscaler", Effect: v1.TaintEffectNoSchedule},
+		{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute},
+	}
+	expected := []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute},
+	}
+	if kept := config.TemplateTaints(taints); !reflect.DeepEqual(expected, kept) {
+		t.Errorf("expected template taints %v, but got: %v", expected, kept)
+	}
+}
+
+func TestParseAutoscalerTemplateTaints(t *testing.T) {
+	cases := []struct {
+		name           string
+		tags           map[string]string
+		expectedTaints []v1.Taint
+		expectedErr    bool
+	}{
+		{
+			name: "auto scaling group tags",
+			tags: map[string]string{
+				"k8s.io/cluster-autoscaler/node-template/taint/dedicated": "gpu:NoSchedule",
+				"k8s.io/cluster-autoscaler/node-template/taint/spot":      ":PreferNoSchedule",
+				"k8s.io/cluster-autoscaler/enabled":                       "true",
+			},
+			expectedTaints: []v1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+			},
+		},
+		{
+			name: "cluster api annotation",
+			tags: map[string]string{
+				"capacity.cluster-autoscaler.kubernetes.io/taints": "dedicated=gpu:NoSchedule,spot:NoExecute",
+			},
+			expectedTaints: []v1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "spot", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:        "invalid effect",
+			tags:        map[string]string{"k8s.io/cluster-autoscaler/node-template/taint/dedicated": "gpu:Sometimes"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, err := ParseAutoscalerTemplateTaints(c.tags)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+	}
+}
+
+func TestSimulateScaleUp(t *testing.T) {
+	template := []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+		{Key: "example.com/initializing", Effect: v1.TaintEffectNoSchedule},
+	}
+	pod := func(name string, tolerations ...v1.Toleration) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ml", Name: name},
+			Spec:       v1.PodSpec{Tolerations: tolerations},
+		}
+	}
+	pods := []v1.Pod{
+		pod("trainer", v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu"}),
+		pod("web"),
+	}
+
+	config := AutoscalerTaintConfig{StartupTaints: []string{"example.com/initializing"}}
+	expected := []WorkloadCheck{
+		{Workload: "ml/trainer", Schedulable: true},
+		{Workload: "ml/web", UntoleratedTaint: &template[0]},
+	}
+	if checks := config.SimulateScaleUp(template, pods); !reflect.DeepEqual(expected, checks) {
+		t.Errorf("expected predictions %+v, but got: %+v", expected, checks)
+	}
+}
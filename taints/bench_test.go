@@ -0,0 +1,114 @@
+package taints
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// The parser sits in admission paths, so it has a performance budget that changes should not
+// regress:
+//
+//   - parsing a simple spec such as 'foo=abc:NoSchedule' does not allocate, and parsing a spec
+//     list allocates at most parseTaintsAllocs times plus parseTaintsAllocsPerSpec times per spec,
+//     which TestParseAllocations asserts,
+//   - parsing a simple spec takes at most parseTaintBudget on a single core, i.e. at least a
+//     million specs per second, and parsing a spec list at most parseTaintsBudget per spec. The
+//     benchmarks report the time per spec, and only fail over budget with -parse-budget, as
+//     timings depend on the machine running them.
+const (
+	parseTaintBudget         = time.Microsecond
+	parseTaintsBudget        = 4 * time.Microsecond
+	parseTaintAllocs         = 0
+	parseTaintsAllocs        = 16
+	parseTaintsAllocsPerSpec = 3
+)
+
+var enforceParseBudget = flag.Bool("parse-budget", false, "fail benchmarks taking longer than the parse budget")
+
+// benchmarkSpec returns a spec list of n simple entries with distinct keys.
+func benchmarkSpec(n int) []string {
+	spec := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		switch i % 3 {
+		case 0:
+			spec = append(spec, fmt.Sprintf("example.com/key-%d=value:NoSchedule", i))
+		case 1:
+			spec = append(spec, fmt.Sprintf("key-%d:NoExecute", i))
+		default:
+			spec = append(spec, fmt.Sprintf("key-%d-", i))
+		}
+	}
+	return spec
+}
+
+// checkParseBudget reports the time per spec, and with -parse-budget fails the benchmark if it
+// took longer than the budget. Runs too short to be measured reliably, such as those estimating
+// b.N, are not checked.
+func checkParseBudget(b *testing.B, specs int, budget time.Duration) {
+	b.Helper()
+	perSpec := b.Elapsed() / time.Duration(b.N*specs)
+	b.ReportMetric(float64(perSpec.Nanoseconds()), "ns/spec")
+	if *enforceParseBudget && b.Elapsed() >= 100*time.Millisecond && perSpec > budget {
+		b.Errorf("expected parsing to take at most %v per spec, but it took %v", budget, perSpec)
+	}
+}
+
+func BenchmarkParseTaint(b *testing.B) {
+	o, err := newOptions(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := o.parseTaint("foo=abc:NoSchedule"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	checkParseBudget(b, 1, parseTaintBudget)
+}
+
+func BenchmarkParseTaints(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		spec := benchmarkSpec(n)
+		b.Run(fmt.Sprintf("specs=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := ParseTaints(spec); err != nil {
+					b.Fatal(err)
+				}
+			}
+			checkParseBudget(b, n, parseTaintsBudget)
+		})
+	}
+}
+
+func BenchmarkParseTaintsAll(b *testing.B) {
+	spec := benchmarkSpec(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := ParseTaintsAll(spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+	checkParseBudget(b, len(spec), parseTaintsBudget)
+}
+
+func TestParseAllocations(t *testing.T) {
+	o, err := newOptions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = o.parseTaint("foo=abc:NoSchedule") }); allocs > parseTaintAllocs {
+		t.Errorf("expected parsing a spec to allocate at most %d times, but got: %v", parseTaintAllocs, allocs)
+	}
+
+	for _, n := range []int{1, 10, 100} {
+		spec := benchmarkSpec(n)
+		budget := float64(parseTaintsAllocs + parseTaintsAllocsPerSpec*n)
+		if allocs := testing.AllocsPerRun(10, func() { _, _, _ = ParseTaints(spec) }); allocs > budget {
+			t.Errorf("expected parsing %d specs to allocate at most %v times, but got: %v", n, budget, allocs)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package taints
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// bundlePrefix marks a spec as a reference to a bundle, such as '@gpu'.
+const bundlePrefix = "@"
+
+// Bundles maps the names of taint bundles, such as "gpu" or "spot", to the specs of the taints
+// they add.
+type Bundles map[string][]string
+
+// LoadBundles reads bundles from YAML or JSON mapping each bundle name to a list of taint specs
+// in canonical form, for example:
+//
+//	gpu:
+//	- nvidia.com/gpu=present:NoSchedule
+//	- dedicated=gpu:NoSchedule
+func LoadBundles(r io.Reader) (Bundles, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var bundles Bundles
+	if err := yaml.UnmarshalStrict(data, &bundles); err != nil {
+		return nil, fmt.Errorf("invalid taint bundles: %v", err)
+	}
+	if err := bundles.validate(); err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// validate checks that every bundle has a name and adds valid taints.
+func (b Bundles) validate() error {
+	for name, spec := range b {
+		if len(name) == 0 || strings.HasPrefix(name, bundlePrefix) || strings.HasSuffix(name, "-") {
+			return fmt.Errorf("invalid taint bundle name: %q", name)
+		}
+		if len(spec) == 0 {
+			return fmt.Errorf("invalid taint bundle %v: no taints", name)
+		}
+		_, taintsToRemove, err := ParseTaints(spec)
+		if err != nil {
+			return fmt.Errorf("invalid taint bundle %v: %v", name, err)
+		}
+		if len(taintsToRemove) > 0 {
+			return fmt.Errorf("invalid taint bundle %v: taints must not be removed", name)
+		}
+	}
+	return nil
+}
+
+// WithBundles expands references to the bundles in specs: '@<name>' adds the taints of the bundle
+// and '@<name>-' removes them. It is an error to reference a bundle that is not defined.
+func WithBundles(bundles Bundles) Option {
+	return func(o *options) error {
+		if err := bundles.validate(); err != nil {
+			return err
+		}
+		o.bundles = bundles
+		return nil
+	}
+}
+
+// expandBundle returns the specs of the taints of a bundle reference, or the spec itself if it
+// does not reference a bundle.
+func (o *options) expandBundle(taintSpec string) ([]string, error) {
+	if o.bundles == nil || !strings.HasPrefix(taintSpec, bundlePrefix) {
+		return []string{taintSpec}, nil
+	}
+	name, remove := strings.CutSuffix(strings.TrimPrefix(taintSpec, bundlePrefix), "-")
+	bundle, ok := o.bundles[name]
+	if !ok {
+		return nil, &SpecError{Spec: taintSpec, Value: name, Err: ErrUnknownBundle, Detail: "unknown taint bundle " + name}
+	}
+	if !remove {
+		return bundle, nil
+	}
+	// removals are written without the values of the taints, which they do not need
+	expanded := make([]string, 0, len(bundle))
+	for _, bundleSpec := range bundle {
+		taint, err := o.parseTaint(bundleSpec)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, FormatRemoval(taint))
+	}
+	return expanded, nil
+}
@@ -0,0 +1,123 @@
+package taints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const testBundles = `
+gpu:
+- nvidia.com/gpu=present:NoSchedule
+- dedicated=gpu:NoSchedule
+spot:
+- cloud.example.com/spot:PreferNoSchedule
+`
+
+func TestLoadBundles(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		expected    Bundles
+		expectedErr bool
+	}{
+		{
+			name:  "valid bundles",
+			input: testBundles,
+			expected: Bundles{
+				"gpu":  {"nvidia.com/gpu=present:NoSchedule", "dedicated=gpu:NoSchedule"},
+				"spot": {"cloud.example.com/spot:PreferNoSchedule"},
+			},
+		},
+		{name: "invalid taint", input: "gpu: [dedicated=gpu]", expectedErr: true},
+		{name: "removal", input: "gpu: [dedicated-]", expectedErr: true},
+		{name: "empty bundle", input: "gpu: []", expectedErr: true},
+		{name: "invalid name", input: "gpu-: [dedicated=gpu:NoSchedule]", expectedErr: true},
+		{name: "invalid yaml", input: "gpu: dedicated=gpu:NoSchedule", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		bundles, err := LoadBundles(strings.NewReader(c.input))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expected, bundles) {
+			t.Errorf("[%s] expected bundles %v, but got: %v", c.name, c.expected, bundles)
+		}
+	}
+}
+
+func TestParseTaintsWithBundles(t *testing.T) {
+	bundles, err := LoadBundles(strings.NewReader(testBundles))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	cases := []struct {
+		name                   string
+		spec                   []string
+		opts                   []Option
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            bool
+	}{
+		{
+			name: "add bundle",
+			spec: []string{"@gpu", "foo:NoExecute"},
+			expectedTaints: []v1.Taint{
+				{Key: "nvidia.com/gpu", Value: "present", Effect: v1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "foo", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:                   "remove bundle",
+			spec:                   []string{"@spot-"},
+			expectedTaintsToRemove: []v1.Taint{{Key: "cloud.example.com/spot", Effect: v1.TaintEffectPreferNoSchedule}},
+		},
+		{
+			name: "remove bundle with values in strict mode",
+			spec: []string{"@gpu-"},
+			opts: []Option{WithStrict()},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "nvidia.com/gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "dedicated", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:        "unknown bundle",
+			spec:        []string{"@maintenance"},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated taint from bundle",
+			spec:        []string{"@gpu", "dedicated=gpu:NoSchedule"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, taintsToRemove, err := ParseTaintsWithOptions(c.spec, append([]Option{WithBundles(bundles)}, c.opts...)...)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+
+	if _, _, err := ParseTaints([]string{"@gpu"}); err == nil {
+		t.Errorf("expected error for a bundle reference without bundles, but got nothing")
+	}
+}
@@ -0,0 +1,106 @@
+package taints
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ConditionRule maps a node condition to a taint.
+type ConditionRule struct {
+	// Condition is the type of the node condition, such as Ready or a custom condition reported by
+	// node-problem-detector.
+	Condition v1.NodeConditionType `json:"condition"`
+	// Status is the status of the condition that applies the taint.
+	Status v1.ConditionStatus `json:"status"`
+	// Taint is the spec of the taint to apply, such as 'example.com/kernel-deadlock:NoExecute'.
+	Taint string `json:"taint"`
+	// GracePeriod is how long the condition must have had the status before the taint applies.
+	GracePeriod time.Duration `json:"gracePeriod,omitempty"`
+}
+
+// conditionRule is a ConditionRule with its taint parsed.
+type conditionRule struct {
+	ConditionRule
+	taint v1.Taint
+}
+
+// ConditionTaintEngine computes the taints of nodes from their conditions, a configurable
+// counterpart to the taints the node lifecycle controller manages for built-in conditions.
+type ConditionTaintEngine struct {
+	rules []conditionRule
+}
+
+// NewConditionTaintEngine returns a ConditionTaintEngine applying the rules. It is an error for a
+// taint spec to be invalid or to be used by rules of different conditions.
+func NewConditionTaintEngine(rules []ConditionRule) (*ConditionTaintEngine, error) {
+	engine := &ConditionTaintEngine{}
+	owners := map[v1.Taint]v1.NodeConditionType{}
+	for _, rule := range rules {
+		taints, _, err := ParseTaints([]string{rule.Taint})
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule for condition %v: %v", rule.Condition, err)
+		}
+		if len(taints) == 0 {
+			return nil, fmt.Errorf("invalid rule for condition %v: %v removes a taint", rule.Condition, rule.Taint)
+		}
+		id := v1.Taint{Key: taints[0].Key, Effect: taints[0].Effect}
+		if owner, ok := owners[id]; ok && owner != rule.Condition {
+			return nil, fmt.Errorf("invalid rule for condition %v: taint %v is already managed for condition %v", rule.Condition, id.ToString(), owner)
+		}
+		owners[id] = rule.Condition
+		engine.rules = append(engine.rules, conditionRule{ConditionRule: rule, taint: taints[0]})
+	}
+	return engine, nil
+}
+
+// Evaluate returns the changes bringing the taints of the node in line with its conditions at the
+// given time. Taints of rules whose condition has had the status for at least the grace period
+// are added, and taints of other rules are removed. Taints not managed by any rule are left
+// alone.
+func (e *ConditionTaintEngine) Evaluate(node *v1.Node, now time.Time) TaintDiff {
+	active := map[v1.Taint]bool{}
+	var desired []v1.Taint
+	for _, rule := range e.rules {
+		id := v1.Taint{Key: rule.taint.Key, Effect: rule.taint.Effect}
+		if active[id] || !conditionHeld(node, rule.Condition, rule.Status, rule.GracePeriod, now) {
+			continue
+		}
+		active[id] = true
+		taint := rule.taint
+		if i := indexOfTaint(node.Spec.Taints, &taint); i >= 0 && node.Spec.Taints[i].Value == taint.Value {
+			taint = node.Spec.Taints[i]
+		}
+		desired = append(desired, taint)
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if !e.manages(&taint) {
+			desired = append(desired, taint)
+		}
+	}
+	return DiffTaints(node.Spec.Taints, desired)
+}
+
+// manages reports whether a rule of the engine applies the taint.
+func (e *ConditionTaintEngine) manages(taint *v1.Taint) bool {
+	for i := range e.rules {
+		if e.rules[i].taint.MatchTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionHeld reports whether the node condition has had the status for at least the grace
+// period.
+func conditionHeld(node *v1.Node, conditionType v1.NodeConditionType, status v1.ConditionStatus, gracePeriod time.Duration, now time.Time) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		return condition.Status == status && !now.Before(condition.LastTransitionTime.Add(gracePeriod))
+	}
+	return false
+}
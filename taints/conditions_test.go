@@ -0,0 +1,122 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewConditionTaintEngine(t *testing.T) {
+	cases := []struct {
+		name        string
+		rules       []ConditionRule
+		expectedErr bool
+	}{
+		{
+			name: "valid rules",
+			rules: []ConditionRule{
+				{Condition: "KernelDeadlock", Status: v1.ConditionTrue, Taint: "example.com/kernel-deadlock:NoExecute"},
+				{Condition: "KernelDeadlock", Status: v1.ConditionUnknown, Taint: "example.com/kernel-deadlock:NoExecute"},
+			},
+		},
+		{
+			name:        "invalid taint spec",
+			rules:       []ConditionRule{{Condition: "KernelDeadlock", Status: v1.ConditionTrue, Taint: "example.com/kernel-deadlock"}},
+			expectedErr: true,
+		},
+		{
+			name:        "removal spec",
+			rules:       []ConditionRule{{Condition: "KernelDeadlock", Status: v1.ConditionTrue, Taint: "example.com/kernel-deadlock:NoExecute-"}},
+			expectedErr: true,
+		},
+		{
+			name: "taint shared by conditions",
+			rules: []ConditionRule{
+				{Condition: "KernelDeadlock", Status: v1.ConditionTrue, Taint: "example.com/broken:NoExecute"},
+				{Condition: "DiskErrors", Status: v1.ConditionTrue, Taint: "example.com/broken:NoExecute"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := NewConditionTaintEngine(c.rules)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+	}
+}
+
+func TestConditionTaintEngineEvaluate(t *testing.T) {
+	engine, err := NewConditionTaintEngine([]ConditionRule{
+		{Condition: "KernelDeadlock", Status: v1.ConditionTrue, Taint: "example.com/kernel-deadlock:NoExecute", GracePeriod: 5 * time.Minute},
+		{Condition: "DiskErrors", Status: v1.ConditionTrue, Taint: "example.com/disk-errors=true:NoSchedule"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	condition := func(conditionType v1.NodeConditionType, status v1.ConditionStatus, since time.Duration) v1.NodeCondition {
+		return v1.NodeCondition{Type: conditionType, Status: status, LastTransitionTime: metav1.NewTime(now.Add(-since))}
+	}
+	unrelated := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	deadlock := v1.Taint{Key: "example.com/kernel-deadlock", Effect: v1.TaintEffectNoExecute}
+	diskErrors := v1.Taint{Key: "example.com/disk-errors", Value: "true", Effect: v1.TaintEffectNoSchedule}
+
+	cases := []struct {
+		name            string
+		taints          []v1.Taint
+		conditions      []v1.NodeCondition
+		expectedAdded   []v1.Taint
+		expectedRemoved []v1.Taint
+	}{
+		{
+			name:       "healthy node",
+			taints:     []v1.Taint{unrelated},
+			conditions: []v1.NodeCondition{condition("KernelDeadlock", v1.ConditionFalse, time.Hour)},
+		},
+		{
+			name:       "condition within its grace period",
+			conditions: []v1.NodeCondition{condition("KernelDeadlock", v1.ConditionTrue, time.Minute)},
+		},
+		{
+			name:          "conditions held",
+			taints:        []v1.Taint{unrelated},
+			conditions:    []v1.NodeCondition{condition("KernelDeadlock", v1.ConditionTrue, 10*time.Minute), condition("DiskErrors", v1.ConditionTrue, 0)},
+			expectedAdded: []v1.Taint{deadlock, diskErrors},
+		},
+		{
+			name:       "taint already applied",
+			taints:     []v1.Taint{deadlock},
+			conditions: []v1.NodeCondition{condition("KernelDeadlock", v1.ConditionTrue, time.Hour)},
+		},
+		{
+			name:            "condition cleared",
+			taints:          []v1.Taint{unrelated, diskErrors},
+			conditions:      []v1.NodeCondition{condition("DiskErrors", v1.ConditionFalse, time.Minute)},
+			expectedRemoved: []v1.Taint{diskErrors},
+		},
+	}
+
+	for _, c := range cases {
+		node := newNode("worker-1", c.taints...)
+		node.Status.Conditions = c.conditions
+		diff := engine.Evaluate(&node, now)
+		if !reflect.DeepEqual(c.expectedAdded, diff.Added) {
+			t.Errorf("[%s] expected added taints %v, but got: %v", c.name, c.expectedAdded, diff.Added)
+		}
+		if !reflect.DeepEqual(c.expectedRemoved, diff.Removed) {
+			t.Errorf("[%s] expected removed taints %v, but got: %v", c.name, c.expectedRemoved, diff.Removed)
+		}
+		if len(diff.Changed) > 0 {
+			t.Errorf("[%s] expected no changed taints, but got: %v", c.name, diff.Changed)
+		}
+	}
+}
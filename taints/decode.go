@@ -0,0 +1,234 @@
+package taints
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Decoder reads taints to be added and taints to be removed from an input format.
+type Decoder interface {
+	Decode(r io.Reader) ([]v1.Taint, []v1.Taint, error)
+}
+
+// DecoderFunc adapts a function to the Decoder interface.
+type DecoderFunc func(r io.Reader) ([]v1.Taint, []v1.Taint, error)
+
+// Decode calls f(r).
+func (f DecoderFunc) Decode(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	return f(r)
+}
+
+var (
+	decodersLock sync.RWMutex
+	// decoders holds the registered decoders by format name.
+	decoders = map[string]Decoder{}
+	// decoderExtensions maps file extensions, including the leading dot, to format names.
+	decoderExtensions = map[string]string{}
+	// formatDetectors are the registered format detectors, in the order they are tried.
+	formatDetectors []formatDetector
+)
+
+// formatDetector detects the input format name from the input.
+type formatDetector struct {
+	name   string
+	detect func(data []byte) bool
+}
+
+func init() {
+	RegisterDecoder("spec", DecoderFunc(decodeSpec), ".txt", ".taints")
+	RegisterDecoder("json", DecoderFunc(decodeJSON), ".json")
+	RegisterDecoder("yaml", DecoderFunc(decodeYAML), ".yaml", ".yml")
+	RegisterDecoder("protobuf", DecoderFunc(decodeProto), ".pb")
+	RegisterDecoder("annotation", DecoderFunc(decodeAnnotation))
+	RegisterDecoder("autoscaler", DecoderFunc(decodeAutoscalerTags))
+
+	RegisterFormatDetector("json", func(data []byte) bool {
+		return bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+	})
+	RegisterFormatDetector("yaml", func(data []byte) bool {
+		line := firstContentLine(data)
+		return line == "-" || strings.HasPrefix(line, "- ")
+	})
+	RegisterFormatDetector("autoscaler", func(data []byte) bool {
+		line := strings.TrimLeft(firstContentLine(data), `{"'`)
+		return strings.HasPrefix(line, autoscalerTemplateTaintTagPrefix) || strings.HasPrefix(line, autoscalerTemplateTaintsAnnotation)
+	})
+}
+
+// RegisterDecoder registers a decoder under a format name, and optionally the file extensions,
+// including the leading dot, that the format is detected from. Registering an existing name or
+// extension replaces it.
+func RegisterDecoder(name string, decoder Decoder, extensions ...string) {
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+
+	decoders[name] = decoder
+	for _, ext := range extensions {
+		decoderExtensions[strings.ToLower(ext)] = name
+	}
+}
+
+// RegisterFormatDetector registers a function detecting the format name from the input, for
+// ParseAny to detect the format of input without one. Detectors are tried in the order they are
+// registered, and registering an existing name replaces its detector in place.
+func RegisterFormatDetector(name string, detect func(data []byte) bool) {
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+
+	for i := range formatDetectors {
+		if formatDetectors[i].name == name {
+			formatDetectors[i].detect = detect
+			return
+		}
+	}
+	formatDetectors = append(formatDetectors, formatDetector{name: name, detect: detect})
+}
+
+// detectFormat returns the name of the format of the first detector matching the input, or spec
+// if there is none.
+func detectFormat(data []byte) string {
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+
+	for _, detector := range formatDetectors {
+		if detector.detect(data) {
+			return detector.name
+		}
+	}
+	return "spec"
+}
+
+// firstContentLine returns the first line of the input that is not blank or a comment, without
+// its comment.
+func firstContentLine(data []byte) string {
+	for len(data) > 0 {
+		var line []byte
+		line, data, _ = bytes.Cut(data, []byte("\n"))
+		if content := stripComment(string(line)); len(content) > 0 {
+			return content
+		}
+	}
+	return ""
+}
+
+// FormatForFile returns the name of the format registered for the extension of the file, or
+// false if there is none.
+func FormatForFile(path string) (string, bool) {
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+
+	name, ok := decoderExtensions[strings.ToLower(filepath.Ext(path))]
+	return name, ok
+}
+
+// ParseAny decodes taints to be added and taints to be removed from r using the decoder
+// registered for format. An empty format is detected by the detectors registered with
+// RegisterFormatDetector, falling back to specs. Among the built-in formats, input starting with
+// '[' is decoded as a JSON array of taints, a YAML sequence as a YAML list of taints, and
+// cluster-autoscaler node template tags as such.
+func ParseAny(format string, r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	if len(format) == 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		format = detectFormat(data)
+		r = bytes.NewReader(data)
+	}
+
+	decodersLock.RLock()
+	decoder, ok := decoders[format]
+	decodersLock.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown input format: %v", format)
+	}
+	return decoder.Decode(r)
+}
+
+// decodeSpec decodes a taints file, as read by ParseTaintsFile.
+func decodeSpec(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	return readTaintsFile(r)
+}
+
+// decodeJSON decodes a JSON array of taints to be added, as found in a node's spec.taints. The
+// taints are validated like their equivalent specs.
+func decodeJSON(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	var taints []v1.Taint
+	if err := json.NewDecoder(r).Decode(&taints); err != nil {
+		return nil, nil, fmt.Errorf("invalid taints: %v", err)
+	}
+	if err := validateDecodedTaints(taints); err != nil {
+		return nil, nil, err
+	}
+	return taints, nil, nil
+}
+
+// decodeYAML decodes a YAML list of taints to be added, with the fields of a node's spec.taints.
+// The taints are validated like their equivalent specs.
+func decodeYAML(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var taints []v1.Taint
+	if err := yaml.UnmarshalStrict(data, &taints); err != nil {
+		return nil, nil, fmt.Errorf("invalid taints: %v", err)
+	}
+	if err := validateDecodedTaints(taints); err != nil {
+		return nil, nil, err
+	}
+	return taints, nil, nil
+}
+
+// decodeAnnotation decodes the value of the 'capacity.cluster-autoscaler.kubernetes.io/taints'
+// annotation, a comma-separated list of specs adding taints, as read by
+// ParseAutoscalerTemplateTaints.
+func decodeAnnotation(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	taints, err := ParseAutoscalerTemplateTaints(map[string]string{
+		autoscalerTemplateTaintsAnnotation: strings.TrimSpace(string(data)),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return taints, nil, nil
+}
+
+// decodeAutoscalerTags decodes a YAML or JSON map of the node template tags of a node group, as
+// read by ParseAutoscalerTemplateTaints.
+func decodeAutoscalerTags(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tags map[string]string
+	if err := yaml.Unmarshal(data, &tags); err != nil {
+		return nil, nil, fmt.Errorf("invalid node template tags: %v", err)
+	}
+	taints, err := ParseAutoscalerTemplateTaints(tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return taints, nil, nil
+}
+
+// validateDecodedTaints validates decoded taints like their equivalent specs.
+func validateDecodedTaints(taints []v1.Taint) error {
+	spec := make([]string, 0, len(taints))
+	for i := range taints {
+		spec = append(spec, taints[i].ToString())
+	}
+	_, _, err := ParseTaints(spec)
+	return err
+}
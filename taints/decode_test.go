@@ -0,0 +1,176 @@
+package taints
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParseAny(t *testing.T) {
+	cases := []struct {
+		name                   string
+		format                 string
+		input                  string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            bool
+	}{
+		{
+			name:        "unknown format",
+			format:      "xml",
+			input:       "<taints/>",
+			expectedErr: true,
+		},
+		{
+			name:   "specs",
+			format: "spec",
+			input:  "foo=abc:NoSchedule\n\n  bar:NoExecute-\n",
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:        "invalid specs",
+			format:      "spec",
+			input:       "foo=abc",
+			expectedErr: true,
+		},
+		{
+			name:   "json",
+			format: "json",
+			input:  `[{"key":"foo","value":"abc","effect":"NoSchedule"}]`,
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:        "json with an invalid taint",
+			format:      "json",
+			input:       `[{"key":"foo","value":"abc"}]`,
+			expectedErr: true,
+		},
+		{
+			name:  "detected json",
+			input: ` [{"key":"foo","effect":"NoExecute"}]`,
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:   "yaml",
+			format: "yaml",
+			input:  "- key: foo\n  value: abc\n  effect: NoSchedule\n",
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:        "yaml with an unknown field",
+			format:      "yaml",
+			input:       "- key: foo\n  effects: NoSchedule\n",
+			expectedErr: true,
+		},
+		{
+			name:   "annotation",
+			format: "annotation",
+			input:  "foo=abc:NoSchedule,bar:NoExecute\n",
+			expectedTaints: []v1.Taint{
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:        "annotation removing a taint",
+			format:      "annotation",
+			input:       "foo:NoSchedule-",
+			expectedErr: true,
+		},
+		{
+			name:   "autoscaler tags",
+			format: "autoscaler",
+			input:  `{"k8s.io/cluster-autoscaler/node-template/taint/dedicated": "gpu:NoSchedule", "team": "ml"}`,
+			expectedTaints: []v1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:  "detected yaml",
+			input: "# taints\n- key: foo\n  effect: NoExecute\n",
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:  "detected autoscaler tags",
+			input: "k8s.io/cluster-autoscaler/node-template/taint/dedicated: gpu:NoSchedule\n",
+			expectedTaints: []v1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:  "detected specs",
+			input: "foo:NoExecute",
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		taints, taintsToRemove, err := ParseAny(c.format, strings.NewReader(c.input))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for input %q, but got nothing", c.name, c.input)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for input %q, but got: %v", c.name, c.input, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("test", DecoderFunc(func(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+		return nil, []v1.Taint{{Key: "test"}}, nil
+	}), ".test")
+
+	format, ok := FormatForFile("/tmp/nodes.TEST")
+	if !ok || format != "test" {
+		t.Fatalf("expected format test, but got: %q", format)
+	}
+	_, taintsToRemove, err := ParseAny(format, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if expected := []v1.Taint{{Key: "test"}}; !reflect.DeepEqual(expected, taintsToRemove) {
+		t.Errorf("expected taints to be removed %v, but got: %v", expected, taintsToRemove)
+	}
+
+	RegisterFormatDetector("test", func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("<test>"))
+	})
+	_, taintsToRemove, err = ParseAny("", strings.NewReader("<test>"))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if expected := []v1.Taint{{Key: "test"}}; !reflect.DeepEqual(expected, taintsToRemove) {
+		t.Errorf("expected detected taints to be removed %v, but got: %v", expected, taintsToRemove)
+	}
+	if format, ok := FormatForFile("nodes.yml"); !ok || format != "yaml" {
+		t.Errorf("expected format yaml, but got: %q", format)
+	}
+	if _, ok := FormatForFile("nodes.xml"); ok {
+		t.Errorf("expected no format for unregistered extension")
+	}
+}
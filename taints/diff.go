@@ -0,0 +1,166 @@
+package taints
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// TaintChange is a taint whose value changes while its key and effect stay the same.
+type TaintChange struct {
+	From v1.Taint `json:"from"`
+	To   v1.Taint `json:"to"`
+}
+
+// TaintDiff describes the changes turning the current taints of a node into the desired ones.
+// Taints are matched by key and effect, and their TimeAdded is ignored.
+type TaintDiff struct {
+	// Current are the taints the diff applies to.
+	Current []v1.Taint    `json:"current,omitempty"`
+	Added   []v1.Taint    `json:"added,omitempty"`
+	Removed []v1.Taint    `json:"removed,omitempty"`
+	Changed []TaintChange `json:"changed,omitempty"`
+}
+
+// DiffTaints returns the changes turning the current taints into the desired taints.
+func DiffTaints(current, desired []v1.Taint) TaintDiff {
+	diff := TaintDiff{Current: current}
+	for i := range current {
+		j := indexOfTaint(desired, &current[i])
+		switch {
+		case j < 0:
+			diff.Removed = append(diff.Removed, current[i])
+		case desired[j].Value != current[i].Value:
+			diff.Changed = append(diff.Changed, TaintChange{From: current[i], To: desired[j]})
+		}
+	}
+	for i := range desired {
+		if indexOfTaint(current, &desired[i]) < 0 {
+			diff.Added = append(diff.Added, desired[i])
+		}
+	}
+	return diff
+}
+
+// InferSpecs returns the specs turning the current taints into the desired taints with
+// `kubectl taint`: specs adding or changing taints, followed by specs removing taints. A key whose
+// taints are all removed, with several effects and none desired, is removed with a single
+// '<key>-' spec. Changing the value of a taint requires the --overwrite flag of kubectl.
+func InferSpecs(current, desired []v1.Taint) []string {
+	diff := DiffTaints(current, desired)
+
+	var spec []string
+	for _, change := range diff.Changed {
+		spec = append(spec, FormatTaint(change.To))
+	}
+	for _, taint := range diff.Added {
+		spec = append(spec, FormatTaint(taint))
+	}
+
+	removedEffects := map[string]int{}
+	for _, taint := range diff.Removed {
+		removedEffects[taint.Key]++
+	}
+	keptKeys := map[string]bool{}
+	for _, taint := range desired {
+		keptKeys[taint.Key] = true
+	}
+	removedKeys := map[string]bool{}
+	for _, taint := range diff.Removed {
+		if removedEffects[taint.Key] > 1 && !keptKeys[taint.Key] {
+			if !removedKeys[taint.Key] {
+				spec = append(spec, FormatRemoval(v1.Taint{Key: taint.Key}))
+				removedKeys[taint.Key] = true
+			}
+			continue
+		}
+		spec = append(spec, FormatRemoval(taint))
+	}
+	return spec
+}
+
+// indexOfTaint returns the index of the taint matching the given one by key and effect, or -1.
+func indexOfTaint(taints []v1.Taint, taint *v1.Taint) int {
+	for i := range taints {
+		if taints[i].MatchTaint(taint) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsEmpty reports whether the diff changes nothing.
+func (d TaintDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Desired returns the taints resulting from applying the diff to the current taints. Current
+// taints keep their order, and added taints are appended.
+func (d TaintDiff) Desired() []v1.Taint {
+	var desired []v1.Taint
+	for i := range d.Current {
+		if indexOfTaint(d.Removed, &d.Current[i]) >= 0 {
+			continue
+		}
+		taint := d.Current[i]
+		for _, change := range d.Changed {
+			if change.From.MatchTaint(&taint) {
+				taint = change.To
+				break
+			}
+		}
+		desired = append(desired, taint)
+	}
+	return append(desired, d.Added...)
+}
+
+// RenderUnified renders the diff as a unified diff between the canonical, sorted specs of the
+// current and desired taints. It returns an empty string if the diff changes nothing.
+func (d TaintDiff) RenderUnified() string {
+	if d.IsEmpty() {
+		return ""
+	}
+
+	formatter := defaultFormatter
+	current := sortedTaints(d.Current)
+	desired := sortedTaints(d.Desired())
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(current) || j < len(desired) {
+		switch {
+		case j == len(desired) || (i < len(current) && lessTaint(&current[i], &desired[j])):
+			lines = append(lines, "-"+formatter.Format(current[i]))
+			i++
+		case i == len(current) || lessTaint(&desired[j], &current[i]):
+			lines = append(lines, "+"+formatter.Format(desired[j]))
+			j++
+		case current[i].Value != desired[j].Value:
+			lines = append(lines, "-"+formatter.Format(current[i]), "+"+formatter.Format(desired[j]))
+			i++
+			j++
+		default:
+			lines = append(lines, " "+formatter.Format(current[i]))
+			i++
+			j++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("--- current\n+++ desired\n")
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(len(current)), hunkRange(len(desired)))
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// hunkRange formats the range of a hunk spanning all n lines of a file.
+func hunkRange(n int) string {
+	if n == 0 {
+		return "0,0"
+	}
+	return fmt.Sprintf("1,%d", n)
+}
@@ -0,0 +1,134 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestDiffTaints(t *testing.T) {
+	current := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "bar", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "baz", Effect: v1.TaintEffectNoExecute},
+	}
+	desired := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+		{Key: "qux", Effect: v1.TaintEffectPreferNoSchedule},
+	}
+
+	diff := DiffTaints(current, desired)
+	expected := TaintDiff{
+		Current: current,
+		Added:   []v1.Taint{{Key: "qux", Effect: v1.TaintEffectPreferNoSchedule}},
+		Removed: []v1.Taint{{Key: "baz", Effect: v1.TaintEffectNoExecute}},
+		Changed: []TaintChange{{From: current[1], To: desired[1]}},
+	}
+	if !reflect.DeepEqual(expected, diff) {
+		t.Errorf("expected diff %v, but got: %v", expected, diff)
+	}
+	if diff.IsEmpty() {
+		t.Errorf("expected diff not to be empty")
+	}
+	if !reflect.DeepEqual(desired, diff.Desired()) {
+		t.Errorf("expected desired taints %v, but got: %v", desired, diff.Desired())
+	}
+	if !DiffTaints(current, current).IsEmpty() {
+		t.Errorf("expected diff of identical taints to be empty")
+	}
+}
+
+func TestRenderUnified(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  []v1.Taint
+		desired  []v1.Taint
+		expected string
+	}{
+		{
+			name:    "no changes",
+			current: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			desired: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			name:    "from no taints",
+			desired: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expected: "--- current\n+++ desired\n" +
+				"@@ -0,0 +1,1 @@\n" +
+				"+foo:NoSchedule\n",
+		},
+		{
+			name: "added, removed, changed and unchanged taints",
+			current: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "baz", Effect: v1.TaintEffectNoExecute},
+			},
+			desired: []v1.Taint{
+				{Key: "qux", Effect: v1.TaintEffectPreferNoSchedule},
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+			},
+			expected: "--- current\n+++ desired\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				"-bar=abc:NoSchedule\n" +
+				"+bar=xyz:NoSchedule\n" +
+				"-baz:NoExecute\n" +
+				" foo=abc:NoSchedule\n" +
+				"+qux:PreferNoSchedule\n",
+		},
+	}
+
+	for _, c := range cases {
+		if rendered := DiffTaints(c.current, c.desired).RenderUnified(); rendered != c.expected {
+			t.Errorf("[%s] expected diff\n%s\nbut got:\n%s", c.name, c.expected, rendered)
+		}
+	}
+}
+
+func TestInferSpecs(t *testing.T) {
+	cases := []struct {
+		name         string
+		current      []v1.Taint
+		desired      []v1.Taint
+		expectedSpec []string
+	}{
+		{
+			name:    "no changes",
+			current: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}},
+			desired: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			name: "add, change and remove",
+			current: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+			desired: []v1.Taint{
+				{Key: "foo", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+				{Key: "baz", Effect: v1.TaintEffectPreferNoSchedule},
+			},
+			expectedSpec: []string{"foo=xyz:NoSchedule", "baz:PreferNoSchedule", "bar:NoExecute-"},
+		},
+		{
+			name: "remove every effect of a key",
+			current: []v1.Taint{
+				{Key: "foo", Effect: v1.TaintEffectNoSchedule},
+				{Key: "foo", Effect: v1.TaintEffectNoExecute},
+				{Key: "bar", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+			desired:      []v1.Taint{{Key: "bar", Effect: v1.TaintEffectPreferNoSchedule}},
+			expectedSpec: []string{"bar:PreferNoSchedule", "foo-", "bar:NoSchedule-", "bar:NoExecute-"},
+		},
+	}
+
+	for _, c := range cases {
+		spec := InferSpecs(c.current, c.desired)
+		if !reflect.DeepEqual(c.expectedSpec, spec) {
+			t.Errorf("[%s] expected spec %v, but got: %v", c.name, c.expectedSpec, spec)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package taints
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Workload is a named set of tolerations, such as those of a pod template.
+type Workload struct {
+	Name        string          `json:"name"`
+	Tolerations []v1.Toleration `json:"tolerations"`
+}
+
+// WriteDOT writes a Graphviz DOT graph linking each workload to the groups of nodes, as grouped
+// by GroupNodesByTaintSignature, that it can be scheduled on, i.e. whose NoSchedule and
+// NoExecute taints it tolerates. Nodes without taints accept every workload and are left out.
+func WriteDOT(w io.Writer, nodes []v1.Node, workloads []Workload) error {
+	groups := GroupNodesByTaintSignature(nodes)
+	signatures := make([]Signature, 0, len(groups))
+	for signature := range groups {
+		if len(signature) > 0 {
+			signatures = append(signatures, signature)
+		}
+	}
+	sort.Slice(signatures, func(i, j int) bool { return signatures[i] < signatures[j] })
+
+	// The taints of each group are those of its first node.
+	groupTaints := map[Signature][]v1.Taint{}
+	for i := range nodes {
+		signature := TaintSignature(nodes[i].Spec.Taints)
+		if _, ok := groupTaints[signature]; !ok {
+			groupTaints[signature] = nodes[i].Spec.Taints
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph taints {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, signature := range signatures {
+		label := fmt.Sprintf("%s\n(%d nodes)", strings.ReplaceAll(string(signature), ",", "\n"), len(groups[signature]))
+		fmt.Fprintf(&b, "  %q [shape=box, label=%s];\n", "group-"+signature.Hash(), dotQuote(label))
+	}
+	for i, workload := range workloads {
+		id := fmt.Sprintf("workload-%d", i)
+		fmt.Fprintf(&b, "  %q [shape=ellipse, label=%s];\n", id, dotQuote(workload.Name))
+		for _, signature := range signatures {
+			if _, untolerated := FindUntoleratedTaint(groupTaints[signature], workload.Tolerations, schedulingEffects); !untolerated {
+				fmt.Fprintf(&b, "  %q -> %q;\n", id, "group-"+signature.Hash())
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes a DOT label, keeping line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
@@ -0,0 +1,45 @@
+package taints
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWriteDOT(t *testing.T) {
+	gpu := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	spot := v1.Taint{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}
+	nodes := []v1.Node{
+		newNode("gpu-1", gpu),
+		newNode("gpu-2", gpu),
+		newNode("spot-1", spot),
+		newNode("plain-1"),
+	}
+	workloads := []Workload{
+		{Name: "trainer", Tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule}}},
+		{Name: "web \"frontend\""},
+	}
+
+	gpuGroup := "group-" + TaintSignature([]v1.Taint{gpu}).Hash()
+	spotGroup := "group-" + TaintSignature([]v1.Taint{spot}).Hash()
+	expected := "digraph taints {\n" +
+		"  rankdir=LR;\n" +
+		fmt.Sprintf("  %q [shape=box, label=\"dedicated=gpu:NoSchedule\\n(2 nodes)\"];\n", gpuGroup) +
+		fmt.Sprintf("  %q [shape=box, label=\"spot:PreferNoSchedule\\n(1 nodes)\"];\n", spotGroup) +
+		"  \"workload-0\" [shape=ellipse, label=\"trainer\"];\n" +
+		fmt.Sprintf("  \"workload-0\" -> %q;\n", gpuGroup) +
+		fmt.Sprintf("  \"workload-0\" -> %q;\n", spotGroup) +
+		"  \"workload-1\" [shape=ellipse, label=\"web \\\"frontend\\\"\"];\n" +
+		fmt.Sprintf("  \"workload-1\" -> %q;\n", spotGroup) +
+		"}\n"
+
+	var out bytes.Buffer
+	if err := WriteDOT(&out, nodes, workloads); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("expected graph\n%s\nbut got:\n%s", expected, out.String())
+	}
+}
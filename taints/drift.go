@@ -0,0 +1,89 @@
+package taints
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeLister lists nodes. It is satisfied by the node client of a client-go clientset, i.e.
+// clientset.CoreV1().Nodes().
+type NodeLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.NodeList, error)
+}
+
+// NodeDrift describes how the taints of a node deviate from the desired state.
+type NodeDrift struct {
+	Node string `json:"node"`
+	// Missing are desired taints that are absent from the node.
+	Missing []v1.Taint `json:"missing,omitempty"`
+	// Changed are desired taints that are present on the node with a different value.
+	Changed []PlannedAdd `json:"changed,omitempty"`
+	// Unwanted are node taints that the desired state removes.
+	Unwanted []v1.Taint `json:"unwanted,omitempty"`
+}
+
+// DriftDetector compares the taints of live nodes against a desired state expressed as taint
+// specs: every taint added by the spec must be present with the same value, and no taint removed
+// by the spec may be present. Taints the spec does not mention are ignored.
+type DriftDetector struct {
+	client   NodeLister
+	selector string
+	adds     []v1.Taint
+	removals []v1.Taint
+}
+
+// NewDriftDetector returns a DriftDetector checking the nodes matching the label selector
+// against the given taint specs. An empty selector checks all nodes.
+func NewDriftDetector(client NodeLister, selector string, spec []string) (*DriftDetector, error) {
+	adds, removals, err := ParseTaints(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &DriftDetector{
+		client:   client,
+		selector: selector,
+		adds:     adds,
+		removals: removals,
+	}, nil
+}
+
+// Detect lists the selected nodes and returns the drift of each node deviating from the desired
+// state, in listing order.
+func (d *DriftDetector) Detect(ctx context.Context) ([]NodeDrift, error) {
+	nodes, err := d.client.List(ctx, metav1.ListOptions{LabelSelector: d.selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []NodeDrift
+	for i := range nodes.Items {
+		if drift, ok := d.driftOf(&nodes.Items[i]); ok {
+			drifts = append(drifts, drift)
+		}
+	}
+	return drifts, nil
+}
+
+// driftOf returns the drift of a single node, and whether it deviates from the desired state.
+func (d *DriftDetector) driftOf(node *v1.Node) (NodeDrift, bool) {
+	drift := NodeDrift{Node: node.Name}
+	for _, planned := range PlanAdds(node.Spec.Taints, d.adds) {
+		switch planned.Action {
+		case AddNew:
+			drift.Missing = append(drift.Missing, planned.Taint)
+		case AddOverwrite:
+			drift.Changed = append(drift.Changed, planned)
+		}
+	}
+	for i := range node.Spec.Taints {
+		for j := range d.removals {
+			if removalMatches(&d.removals[j], &node.Spec.Taints[i]) {
+				drift.Unwanted = append(drift.Unwanted, node.Spec.Taints[i])
+				break
+			}
+		}
+	}
+	return drift, len(drift.Missing) > 0 || len(drift.Changed) > 0 || len(drift.Unwanted) > 0
+}
@@ -0,0 +1,72 @@
+package taints
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeNodeLister []v1.Node
+
+func (f fakeNodeLister) List(_ context.Context, _ metav1.ListOptions) (*v1.NodeList, error) {
+	return &v1.NodeList{Items: f}, nil
+}
+
+func newNode(name string, taints ...v1.Taint) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.NodeSpec{Taints: taints},
+	}
+}
+
+func TestDriftDetector(t *testing.T) {
+	client := fakeNodeLister{
+		newNode("in-sync",
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "unrelated", Effect: v1.TaintEffectPreferNoSchedule}),
+		newNode("missing"),
+		newNode("changed",
+			v1.Taint{Key: "dedicated", Value: "cpu", Effect: v1.TaintEffectNoSchedule}),
+		newNode("unwanted",
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "maintenance", Effect: v1.TaintEffectNoExecute}),
+	}
+
+	detector, err := NewDriftDetector(client, "", []string{"dedicated=gpu:NoSchedule", "maintenance-"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	drifts, err := detector.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	expected := []NodeDrift{
+		{
+			Node:    "missing",
+			Missing: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			Node: "changed",
+			Changed: []PlannedAdd{
+				{Taint: v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}, Action: AddOverwrite, OldValue: "cpu"},
+			},
+		},
+		{
+			Node:     "unwanted",
+			Unwanted: []v1.Taint{{Key: "maintenance", Effect: v1.TaintEffectNoExecute}},
+		},
+	}
+	if !reflect.DeepEqual(expected, drifts) {
+		t.Errorf("expected drifts %v, but got: %v", expected, drifts)
+	}
+}
+
+func TestNewDriftDetectorInvalidSpec(t *testing.T) {
+	if _, err := NewDriftDetector(fakeNodeLister{}, "", []string{"foo"}); err == nil {
+		t.Errorf("expected error for invalid spec, but got nothing")
+	}
+}
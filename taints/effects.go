@@ -0,0 +1,76 @@
+package taints
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// EffectTranslation maps the effect names used by an external system to taint effects, e.g.
+// "HARD" to NoSchedule. Each effect may have at most one name, so that the table can be used in
+// both directions.
+type EffectTranslation map[string]v1.TaintEffect
+
+// Name returns the external name of the effect, or false if the table has none.
+func (t EffectTranslation) Name(effect v1.TaintEffect) (string, bool) {
+	for name, e := range t {
+		if e == effect {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// validate checks that the table maps every effect from at most one name.
+func (t EffectTranslation) validate() error {
+	names := map[v1.TaintEffect]string{}
+	for name, effect := range t {
+		if other, ok := names[effect]; ok {
+			return fmt.Errorf("invalid effect translation: %v and %v both translate to %v", other, name, effect)
+		}
+		names[effect] = name
+	}
+	return nil
+}
+
+// WithEffectTranslation accepts the external effect names of the table in specs, in addition to
+// the canonical effect names, and translates them to their taint effects.
+func WithEffectTranslation(table EffectTranslation) Option {
+	return func(o *options) error {
+		if err := table.validate(); err != nil {
+			return err
+		}
+		o.effectTranslation = table
+		return nil
+	}
+}
+
+// WithCaseInsensitiveEffects accepts effect names regardless of case and of '-', '_' or space
+// separators between words, such as 'noschedule', 'NOEXECUTE' or 'prefer-no-schedule', and
+// normalizes them to the canonical taint effects.
+func WithCaseInsensitiveEffects() Option {
+	return func(o *options) error {
+		o.caseInsensitiveEffects = true
+		return nil
+	}
+}
+
+// effectSeparators are removed from effect names compared case-insensitively.
+var effectSeparators = strings.NewReplacer("-", "", "_", "", " ", "")
+
+// translateEffect returns the taint effect named in a spec.
+func (o *options) translateEffect(name string) v1.TaintEffect {
+	if effect, ok := o.effectTranslation[name]; ok {
+		return effect
+	}
+	if o.caseInsensitiveEffects && len(name) > 0 {
+		folded := effectSeparators.Replace(name)
+		for effect := range o.profile.effects {
+			if strings.EqualFold(folded, string(effect)) {
+				return effect
+			}
+		}
+	}
+	return v1.TaintEffect(name)
+}
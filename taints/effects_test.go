@@ -0,0 +1,136 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+var testEffectTranslation = EffectTranslation{
+	"HARD":  v1.TaintEffectNoSchedule,
+	"SOFT":  v1.TaintEffectPreferNoSchedule,
+	"EVICT": v1.TaintEffectNoExecute,
+}
+
+func TestWithEffectTranslation(t *testing.T) {
+	cases := []struct {
+		name                   string
+		table                  EffectTranslation
+		spec                   []string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            bool
+	}{
+		{
+			name: "table with an effect named twice",
+			table: EffectTranslation{
+				"HARD":   v1.TaintEffectNoSchedule,
+				"STRICT": v1.TaintEffectNoSchedule,
+			},
+			spec:        []string{"foo=abc:HARD"},
+			expectedErr: true,
+		},
+		{
+			name:  "translated and canonical effects",
+			table: testEffectTranslation,
+			spec:  []string{"foo=abc:HARD", "bar:NoSchedule", "baz:EVICT-"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoSchedule},
+			},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "baz", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:        "unknown external effect",
+			table:       testEffectTranslation,
+			spec:        []string{"foo=abc:FIRM"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, taintsToRemove, err := ParseTaintsWithOptions(c.spec, WithEffectTranslation(c.table))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+}
+
+func TestEffectTranslationName(t *testing.T) {
+	if name, ok := testEffectTranslation.Name(v1.TaintEffectNoExecute); !ok || name != "EVICT" {
+		t.Errorf("expected name EVICT, but got: %q", name)
+	}
+	if name, ok := testEffectTranslation.Name("Unknown"); ok {
+		t.Errorf("expected no name, but got: %q", name)
+	}
+}
+
+func TestWithCaseInsensitiveEffects(t *testing.T) {
+	cases := []struct {
+		name                   string
+		opts                   []Option
+		spec                   []string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            bool
+	}{
+		{
+			name:        "case-sensitive by default",
+			spec:        []string{"foo=abc:noschedule"},
+			expectedErr: true,
+		},
+		{
+			name: "cases and aliases",
+			opts: []Option{WithCaseInsensitiveEffects()},
+			spec: []string{"foo=abc:noschedule", "bar:NOEXECUTE", "baz:prefer-no-schedule", "qux:No_Execute-"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+				{Key: "baz", Effect: v1.TaintEffectPreferNoSchedule},
+			},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "qux", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:           "with effect translation",
+			opts:           []Option{WithCaseInsensitiveEffects(), WithEffectTranslation(testEffectTranslation)},
+			spec:           []string{"foo=abc:HARD", "bar:no-execute"},
+			expectedTaints: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}, {Key: "bar", Effect: v1.TaintEffectNoExecute}},
+		},
+		{
+			name:        "unknown effect",
+			opts:        []Option{WithCaseInsensitiveEffects()},
+			spec:        []string{"foo=abc:no-scheduling"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, taintsToRemove, err := ParseTaintsWithOptions(c.spec, c.opts...)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+}
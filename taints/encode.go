@@ -0,0 +1,117 @@
+package taints
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Encoder writes a value, such as nodes, a Report or lint findings, in an output format.
+type Encoder interface {
+	Encode(w io.Writer, v interface{}) error
+}
+
+// EncoderFunc adapts a function to the Encoder interface.
+type EncoderFunc func(w io.Writer, v interface{}) error
+
+// Encode calls f(w, v).
+func (f EncoderFunc) Encode(w io.Writer, v interface{}) error {
+	return f(w, v)
+}
+
+var (
+	encodersLock sync.RWMutex
+	// encoders holds the registered encoders by format name.
+	encoders = map[string]Encoder{}
+)
+
+// The built-in encoders write any value as JSON or YAML, nodes as an inventory with WriteCSV or
+// WriteTSV or as a graph of their taints with WriteDOT, a Report with RenderMarkdown or
+// RenderHTML, and lint findings with WriteSARIF. Other values are an error.
+func init() {
+	RegisterEncoder("json", EncoderFunc(encodeJSON))
+	RegisterEncoder("yaml", EncoderFunc(encodeYAML))
+	RegisterEncoder("csv", nodesEncoder("csv", WriteCSV))
+	RegisterEncoder("tsv", nodesEncoder("tsv", WriteTSV))
+	RegisterEncoder("dot", nodesEncoder("dot", func(w io.Writer, nodes []v1.Node) error {
+		return WriteDOT(w, nodes, nil)
+	}))
+	RegisterEncoder("markdown", reportEncoder("markdown", Report.RenderMarkdown))
+	RegisterEncoder("html", reportEncoder("html", Report.RenderHTML))
+	RegisterEncoder("sarif", EncoderFunc(encodeSARIF))
+}
+
+// RegisterEncoder registers an encoder under a format name. Registering an existing name replaces
+// it.
+func RegisterEncoder(name string, encoder Encoder) {
+	encodersLock.Lock()
+	defer encodersLock.Unlock()
+
+	encoders[name] = encoder
+}
+
+// EncoderFor returns the encoder registered for the format name, or false if there is none.
+func EncoderFor(name string) (Encoder, bool) {
+	encodersLock.RLock()
+	defer encodersLock.RUnlock()
+
+	encoder, ok := encoders[name]
+	return encoder, ok
+}
+
+// unsupportedValueError returns the error of an encoder for a value it cannot write.
+func unsupportedValueError(format string, v interface{}) error {
+	return fmt.Errorf("%v output does not support %T", format, v)
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// encodeYAML writes the value as YAML, with the field names of its JSON encoding.
+func encodeYAML(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// nodesEncoder returns an Encoder writing nodes with write.
+func nodesEncoder(format string, write func(io.Writer, []v1.Node) error) Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		nodes, ok := v.([]v1.Node)
+		if !ok {
+			return unsupportedValueError(format, v)
+		}
+		return write(w, nodes)
+	})
+}
+
+// reportEncoder returns an Encoder writing a Report with render.
+func reportEncoder(format string, render func(Report, io.Writer) error) Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		report, ok := v.(Report)
+		if !ok {
+			return unsupportedValueError(format, v)
+		}
+		return render(report, w)
+	})
+}
+
+// encodeSARIF writes lint findings as a SARIF log. As the file of the findings is not known, their
+// locations have no URI.
+func encodeSARIF(w io.Writer, v interface{}) error {
+	findings, ok := v.([]LintFinding)
+	if !ok {
+		return unsupportedValueError("sarif", v)
+	}
+	return WriteSARIF(w, "", findings)
+}
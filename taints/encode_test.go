@@ -0,0 +1,142 @@
+package taints
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestEncoderFor(t *testing.T) {
+	nodes := []v1.Node{newNode("node-1", v1.Taint{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule})}
+	report := EffectReport("node-1", nodes[0].Spec.Taints)
+	findings := Lint([]string{"foo=abc"})
+
+	cases := []struct {
+		name        string
+		format      string
+		value       interface{}
+		write       func(w io.Writer) error
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "json",
+			format:   "json",
+			value:    nodes[0].Spec.Taints,
+			expected: "[\n  {\n    \"key\": \"foo\",\n    \"value\": \"abc\",\n    \"effect\": \"NoSchedule\"\n  }\n]\n",
+		},
+		{
+			name:     "yaml",
+			format:   "yaml",
+			value:    nodes[0].Spec.Taints,
+			expected: "- effect: NoSchedule\n  key: foo\n  value: abc\n",
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			value:  nodes,
+			write:  func(w io.Writer) error { return WriteCSV(w, nodes) },
+		},
+		{
+			name:   "tsv",
+			format: "tsv",
+			value:  nodes,
+			write:  func(w io.Writer) error { return WriteTSV(w, nodes) },
+		},
+		{
+			name:   "dot",
+			format: "dot",
+			value:  nodes,
+			write:  func(w io.Writer) error { return WriteDOT(w, nodes, nil) },
+		},
+		{
+			name:   "markdown",
+			format: "markdown",
+			value:  report,
+			write:  report.RenderMarkdown,
+		},
+		{
+			name:   "html",
+			format: "html",
+			value:  report,
+			write:  report.RenderHTML,
+		},
+		{
+			name:   "sarif",
+			format: "sarif",
+			value:  findings,
+			write:  func(w io.Writer) error { return WriteSARIF(w, "", findings) },
+		},
+		{
+			name:        "unsupported value",
+			format:      "csv",
+			value:       report,
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		encoder, ok := EncoderFor(c.format)
+		if !ok {
+			t.Errorf("[%s] expected encoder for format %v, but got none", c.name, c.format)
+			continue
+		}
+		var b bytes.Buffer
+		err := encoder.Encode(&b, c.value)
+		if c.expectedErr {
+			if err == nil {
+				t.Errorf("[%s] expected error, but got nothing", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+			continue
+		}
+		expected := c.expected
+		if c.write != nil {
+			var w bytes.Buffer
+			if err := c.write(&w); err != nil {
+				t.Fatalf("[%s] expected no error, but got: %v", c.name, err)
+			}
+			expected = w.String()
+		}
+		if b.String() != expected {
+			t.Errorf("[%s] expected output %q, but got: %q", c.name, expected, b.String())
+		}
+	}
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("ticket", EncoderFunc(func(w io.Writer, v interface{}) error {
+		taints, ok := v.([]v1.Taint)
+		if !ok {
+			return unsupportedValueError("ticket", v)
+		}
+		_, err := io.WriteString(w, "CHANGE: "+strings.Join(FormatTaints(taints, nil), " ")+"\n")
+		return err
+	}))
+	defer func() {
+		encodersLock.Lock()
+		delete(encoders, "ticket")
+		encodersLock.Unlock()
+	}()
+
+	encoder, ok := EncoderFor("ticket")
+	if !ok {
+		t.Fatalf("expected registered encoder, but got none")
+	}
+	var b bytes.Buffer
+	if err := encoder.Encode(&b, []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}}); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if expected := "CHANGE: foo:NoSchedule\n"; b.String() != expected {
+		t.Errorf("expected output %q, but got: %q", expected, b.String())
+	}
+	if _, ok := EncoderFor("xml"); ok {
+		t.Errorf("expected no encoder for unknown format")
+	}
+}
@@ -0,0 +1,142 @@
+package taints
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Errors wrapped by the errors of ParseTaints, telling the class of a failure apart with errors.Is.
+var (
+	// ErrEmptySpec is a spec that is empty or only holds whitespace.
+	ErrEmptySpec = errors.New("empty taint spec")
+	// ErrInvalidSpec is a spec that is not of any of the accepted forms.
+	ErrInvalidSpec = errors.New("invalid taint spec")
+	// ErrEmptyRemovalKey is a removal spec without a key, such as '-' or ':NoSchedule-'.
+	ErrEmptyRemovalKey = errors.New("empty taint removal key")
+	// ErrInvalidKey is a spec whose key is not a qualified name.
+	ErrInvalidKey = errors.New("invalid taint key")
+	// ErrInvalidValue is a spec whose value is not a valid label value.
+	ErrInvalidValue = errors.New("invalid taint value")
+	// ErrInvalidEffect is a spec whose effect is not supported.
+	ErrInvalidEffect = errors.New("invalid taint effect")
+	// ErrMissingEffect is a spec adding a taint without an effect.
+	ErrMissingEffect = errors.New("missing taint effect")
+	// ErrUnknownBundle is a reference to a bundle that is not defined.
+	ErrUnknownBundle = errors.New("unknown taint bundle")
+	// ErrDuplicateTaint is specs adding taints with the same key and effect.
+	ErrDuplicateTaint = errors.New("duplicated taints")
+)
+
+// SpecError is the error of an invalid spec. It wraps one of the Err* errors describing the
+// failure.
+type SpecError struct {
+	// Spec is the offending spec, without the '-' suffix of removals.
+	Spec string
+	// Field is the part of the spec that failed validation: "key", "value" or "effect", or empty
+	// if the spec is malformed as a whole.
+	Field string
+	// Value is the offending text of the field.
+	Value string
+	// Err is the class of the failure.
+	Err error
+	// Detail holds the reasons given by validation, if any.
+	Detail string
+	// Suggestion is the accepted value closest to a misspelled one, if any.
+	Suggestion string
+	// Offset is the byte offset in Spec at which the failure was found.
+	Offset int
+
+	// messages renders the message of the error.
+	messages messages
+}
+
+func (e *SpecError) Error() string {
+	return e.messages.render(e.Reason(), e)
+}
+
+// Reason returns the reason code of the failure.
+func (e *SpecError) Reason() Reason {
+	return reasons[e.Err]
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateTaint is a key and effect added by several specs.
+type DuplicateTaint struct {
+	Key    string         `json:"key"`
+	Effect v1.TaintEffect `json:"effect"`
+	// Indices are the positions of the specs adding the taint.
+	Indices []int `json:"indices"`
+}
+
+// String describes the duplicate as in "foo:NoSchedule (specs 0, 2)".
+func (d DuplicateTaint) String() string {
+	indices := make([]string, 0, len(d.Indices))
+	for _, i := range d.Indices {
+		indices = append(indices, fmt.Sprint(i))
+	}
+	taint := v1.Taint{Key: d.Key, Effect: d.Effect}
+	return fmt.Sprintf("%v (specs %v)", taint.ToString(), strings.Join(indices, ", "))
+}
+
+// DuplicateTaintError is returned when several specs add taints with the same key and effect. It
+// lists every duplicate rather than only the first one.
+type DuplicateTaintError struct {
+	Duplicates []DuplicateTaint
+
+	// messages renders the message of the error.
+	messages messages
+}
+
+func (e *DuplicateTaintError) Error() string {
+	return e.messages.render(ReasonDuplicateTaint, e)
+}
+
+// Reason returns the reason code of the failure.
+func (e *DuplicateTaintError) Reason() Reason {
+	return ReasonDuplicateTaint
+}
+
+func (e *DuplicateTaintError) Unwrap() error {
+	return ErrDuplicateTaint
+}
+
+// ParseError is the error of an entry of a spec list.
+type ParseError struct {
+	// Index is the position of the entry in the list.
+	Index int
+	Spec  string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("spec %d: %v", e.Index, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors lists the errors of every invalid entry of a spec list, ordered by index.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,140 @@
+package taints
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestDuplicateTaintError(t *testing.T) {
+	spec := []string{"foo=abc:NoSchedule", "bar:NoExecute", "foo=xyz:NoSchedule", "bar:NoExecute", "foo:NoExecute", "foo:NoSchedule"}
+	_, _, err := ParseTaints(spec)
+
+	var duplicateErr *DuplicateTaintError
+	if !errors.As(err, &duplicateErr) {
+		t.Fatalf("expected a DuplicateTaintError, but got: %v", err)
+	}
+	expected := []DuplicateTaint{
+		{Key: "foo", Effect: v1.TaintEffectNoSchedule, Indices: []int{0, 2, 5}},
+		{Key: "bar", Effect: v1.TaintEffectNoExecute, Indices: []int{1, 3}},
+	}
+	if !reflect.DeepEqual(expected, duplicateErr.Duplicates) {
+		t.Errorf("expected duplicates %+v, but got: %+v", expected, duplicateErr.Duplicates)
+	}
+	expectedMessage := "duplicated taints with the same key and effect: foo:NoSchedule (specs 0, 2, 5); bar:NoExecute (specs 1, 3)"
+	if err.Error() != expectedMessage {
+		t.Errorf("expected error %q, but got: %q", expectedMessage, err.Error())
+	}
+}
+
+func TestSpecError(t *testing.T) {
+	cases := []struct {
+		name          string
+		spec          []string
+		expectedErr   error
+		expectedField string
+		expectedValue string
+	}{
+		{name: "empty spec", spec: []string{""}, expectedErr: ErrEmptySpec},
+		{name: "whitespace spec", spec: []string{" "}, expectedErr: ErrEmptySpec},
+		{name: "malformed spec", spec: []string{"foo=abc=xyz:NoSchedule"}, expectedErr: ErrInvalidSpec},
+		{name: "invalid key", spec: []string{"foo bar:NoSchedule"}, expectedErr: ErrInvalidKey, expectedField: "key", expectedValue: "foo bar"},
+		{name: "invalid value", spec: []string{"foo=-abc:NoSchedule"}, expectedErr: ErrInvalidValue, expectedField: "value", expectedValue: "-abc"},
+		{name: "invalid effect", spec: []string{"foo:NoScheduel-"}, expectedErr: ErrInvalidEffect, expectedField: "effect", expectedValue: "NoScheduel"},
+		{name: "value without effect", spec: []string{"foo=abc"}, expectedErr: ErrInvalidKey, expectedField: "key", expectedValue: "foo=abc"},
+		{name: "missing effect without value", spec: []string{"foo"}, expectedErr: ErrMissingEffect, expectedField: "effect"},
+		{name: "duplicated taints", spec: []string{"foo:NoSchedule", "foo:NoSchedule"}, expectedErr: ErrDuplicateTaint},
+		{name: "removal without key", spec: []string{"-"}, expectedErr: ErrEmptyRemovalKey, expectedField: "key"},
+		{name: "removal with only an empty effect", spec: []string{":-"}, expectedErr: ErrEmptyRemovalKey, expectedField: "key"},
+		{name: "removal with only separators", spec: []string{"=:-"}, expectedErr: ErrEmptyRemovalKey, expectedField: "key"},
+		{name: "removal with only an effect", spec: []string{":NoSchedule-"}, expectedErr: ErrEmptyRemovalKey, expectedField: "key"},
+		{name: "removal of an invalid key", spec: []string{"/-"}, expectedErr: ErrInvalidKey, expectedField: "key", expectedValue: "/"},
+	}
+
+	for _, c := range cases {
+		_, _, err := ParseTaints(c.spec)
+		if !errors.Is(err, c.expectedErr) {
+			t.Errorf("[%s] expected error %v, but got: %v", c.name, c.expectedErr, err)
+			continue
+		}
+		var specErr *SpecError
+		if !errors.As(err, &specErr) {
+			continue
+		}
+		if specErr.Field != c.expectedField || specErr.Value != c.expectedValue {
+			t.Errorf("[%s] expected field %q with value %q, but got: %q with value %q", c.name, c.expectedField, c.expectedValue, specErr.Field, specErr.Value)
+		}
+	}
+}
+
+func TestParseTaintsAll(t *testing.T) {
+	spec := []string{"foo=abc:NoSchedule", "bar", "foo=xyz:NoSchedule", "baz:Never", "qux-"}
+	_, _, err := ParseTaintsAll(spec)
+
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ParseErrors, but got: %v", err)
+	}
+	expected := []struct {
+		index int
+		err   error
+	}{
+		{index: 1, err: ErrMissingEffect},
+		{index: 2, err: ErrDuplicateTaint},
+		{index: 3, err: ErrInvalidEffect},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, but got: %v", len(expected), errs)
+	}
+	for i, e := range expected {
+		if errs[i].Index != e.index || errs[i].Spec != spec[e.index] || !errors.Is(errs[i], e.err) {
+			t.Errorf("expected error %v for spec %d, but got: %v", e.err, e.index, errs[i])
+		}
+	}
+	if !errors.Is(err, ErrInvalidEffect) {
+		t.Errorf("expected the aggregate to match ErrInvalidEffect")
+	}
+	expectedMessage := "spec 1: invalid taint spec: bar; " +
+		"spec 2: duplicated taints with the same key and effect: foo:NoSchedule (specs 0, 2); " +
+		"spec 3: invalid taint effect: Never, unsupported taint effect"
+	if err.Error() != expectedMessage {
+		t.Errorf("expected error %q, but got: %q", expectedMessage, err.Error())
+	}
+
+	taints, taintsToRemove, err := ParseTaintsAll([]string{"foo=abc:NoSchedule", "qux-"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if len(taints) != 1 || len(taintsToRemove) != 1 {
+		t.Errorf("expected a taint and a removal, but got: %v and %v", taints, taintsToRemove)
+	}
+}
+
+func TestParseTaintsLenient(t *testing.T) {
+	spec := []string{"foo=abc:NoSchedule", "bar", "foo=xyz:NoSchedule", "baz:NoExecute", "qux-", "-"}
+	taints, taintsToRemove, errs := ParseTaintsLenient(spec)
+
+	expectedTaints := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "baz", Effect: v1.TaintEffectNoExecute},
+	}
+	if !reflect.DeepEqual(expectedTaints, taints) {
+		t.Errorf("expected taints %v, but got: %v", expectedTaints, taints)
+	}
+	if expected := []v1.Taint{{Key: "qux"}}; !reflect.DeepEqual(expected, taintsToRemove) {
+		t.Errorf("expected taints to be removed %v, but got: %v", expected, taintsToRemove)
+	}
+	var indices []int
+	for _, err := range errs {
+		indices = append(indices, err.Index)
+	}
+	if expected := []int{1, 2, 5}; !reflect.DeepEqual(expected, indices) {
+		t.Errorf("expected errors for specs %v, but got: %v", expected, errs)
+	}
+
+	if _, _, errs := ParseTaintsLenient([]string{"foo:NoSchedule"}); errs != nil {
+		t.Errorf("expected no errors, but got: %v", errs)
+	}
+}
@@ -0,0 +1,159 @@
+package taints
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Formatter renders taints as specs in a consistent style, so that every emitter produces the
+// same text for the same taints.
+type Formatter struct {
+	o *options
+}
+
+// defaultFormatter writes specs in the default style.
+var defaultFormatter = &Formatter{o: &options{}}
+
+// NewFormatter returns a Formatter whose style is adjusted by the given options. By default,
+// taints are written as '<key>=<value>:<effect>', or '<key>:<effect>' if the value is empty, and
+// removals as '<key>:<effect>-', or '<key>-' if the effect is empty.
+func NewFormatter(opts ...Option) (*Formatter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Formatter{o: o}, nil
+}
+
+// WithEmptyValueSeparator makes formatters write the '=' separator for taints with an empty
+// value, i.e. '<key>=:<effect>'.
+func WithEmptyValueSeparator() Option {
+	return func(o *options) error {
+		o.emptyValueSeparator = true
+		return nil
+	}
+}
+
+// WithSortedOutput orders parsed and formatted taints by key, effect and value, instead of the
+// order of their specs.
+func WithSortedOutput() Option {
+	return func(o *options) error {
+		o.sorted = true
+		return nil
+	}
+}
+
+// Format returns the spec adding the taint. The ':' separator is left out for a taint without an
+// effect, which cannot be added, so that the spec fails to parse for a missing effect.
+func (f *Formatter) Format(taint v1.Taint) string {
+	var b strings.Builder
+	b.WriteString(taint.Key)
+	if len(taint.Value) > 0 || f.o.emptyValueSeparator {
+		b.WriteString("=")
+		b.WriteString(taint.Value)
+	}
+	if len(taint.Effect) > 0 {
+		b.WriteString(":")
+		b.WriteString(f.effectName(taint.Effect))
+	}
+	return b.String()
+}
+
+// FormatRemoval returns the spec removing the taint. The value of the taint is not part of it.
+func (f *Formatter) FormatRemoval(taint v1.Taint) string {
+	if len(taint.Effect) == 0 {
+		return taint.Key + "-"
+	}
+	return taint.Key + ":" + f.effectName(taint.Effect) + "-"
+}
+
+// FormatAll returns the specs adding the taints followed by the specs removing the taints to be
+// removed, the inverse of ParseTaints.
+func (f *Formatter) FormatAll(taints, taintsToRemove []v1.Taint) []string {
+	if f.o.sorted {
+		taints = sortedTaints(taints)
+		taintsToRemove = sortedTaints(taintsToRemove)
+	}
+	spec := make([]string, 0, len(taints)+len(taintsToRemove))
+	for _, taint := range taints {
+		spec = append(spec, f.Format(taint))
+	}
+	for _, taint := range taintsToRemove {
+		spec = append(spec, f.FormatRemoval(taint))
+	}
+	return spec
+}
+
+// FormatTaint returns the canonical spec adding the taint, '<key>=<value>:<effect>', or
+// '<key>:<effect>' if the value is empty.
+func FormatTaint(taint v1.Taint) string {
+	return defaultFormatter.Format(taint)
+}
+
+// FormatRemoval returns the canonical spec removing the taint, '<key>:<effect>-', or '<key>-' if
+// the effect is empty.
+func FormatRemoval(taint v1.Taint) string {
+	return defaultFormatter.FormatRemoval(taint)
+}
+
+// FormatTaints returns the canonical specs adding the taints followed by the specs removing the
+// taints to be removed, such that ParseTaints returns the same taints for them.
+func FormatTaints(taints, taintsToRemove []v1.Taint) []string {
+	return defaultFormatter.FormatAll(taints, taintsToRemove)
+}
+
+// Normalize validates the spec like ParseTaints and returns it with each entry in canonical form,
+// keeping their order. Equivalent forms such as 'foobar=:NoSchedule' and 'foobar:NoSchedule', or
+// 'foo=abc:NoSchedule-' and 'foo:NoSchedule-', normalize to the same entry.
+func Normalize(spec []string) ([]string, error) {
+	result, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	// every entry adds or removes a single taint, written back at the index of the entry
+	normalized := make([]string, len(spec))
+	for i, taint := range result.Adds {
+		normalized[result.AddIndices[i]] = FormatTaint(taint)
+	}
+	for i, removal := range result.TaintRemovals {
+		normalized[result.RemovalIndices[i]] = FormatRemoval(removal.Taint())
+	}
+	return normalized, nil
+}
+
+func (f *Formatter) effectName(effect v1.TaintEffect) string {
+	if name, ok := f.o.effectTranslation.Name(effect); ok {
+		return name
+	}
+	return string(effect)
+}
+
+// sortedTaints returns a copy of the taints ordered by key, effect and value.
+func sortedTaints(taints []v1.Taint) []v1.Taint {
+	if taints == nil {
+		return nil
+	}
+	sorted := append([]v1.Taint(nil), taints...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return lessTaintAndValue(&sorted[i], &sorted[j])
+	})
+	return sorted
+}
+
+// lessTaintAndValue orders taints by key, effect, then value.
+func lessTaintAndValue(a, b *v1.Taint) bool {
+	if lessTaint(a, b) {
+		return true
+	}
+	return !lessTaint(b, a) && a.Value < b.Value
+}
+
+// lessTaint orders taints by key, then effect.
+func lessTaint(a, b *v1.Taint) bool {
+	if a.Key != b.Key {
+		return a.Key < b.Key
+	}
+	return a.Effect < b.Effect
+}
@@ -0,0 +1,132 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestFormatter(t *testing.T) {
+	taints := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "bar", Effect: v1.TaintEffectNoExecute},
+	}
+	taintsToRemove := []v1.Taint{
+		{Key: "qux", Effect: v1.TaintEffectNoSchedule},
+		{Key: "baz", Value: "ignored"},
+	}
+
+	cases := []struct {
+		name         string
+		opts         []Option
+		expectedSpec []string
+	}{
+		{
+			name:         "default style",
+			expectedSpec: []string{"foo=abc:NoSchedule", "bar:NoExecute", "qux:NoSchedule-", "baz-"},
+		},
+		{
+			name:         "empty value separator",
+			opts:         []Option{WithEmptyValueSeparator()},
+			expectedSpec: []string{"foo=abc:NoSchedule", "bar=:NoExecute", "qux:NoSchedule-", "baz-"},
+		},
+		{
+			name:         "translated effects",
+			opts:         []Option{WithEffectTranslation(testEffectTranslation)},
+			expectedSpec: []string{"foo=abc:HARD", "bar:EVICT", "qux:HARD-", "baz-"},
+		},
+		{
+			name:         "sorted output",
+			opts:         []Option{WithSortedOutput()},
+			expectedSpec: []string{"bar:NoExecute", "foo=abc:NoSchedule", "baz-", "qux:NoSchedule-"},
+		},
+	}
+
+	for _, c := range cases {
+		formatter, err := NewFormatter(c.opts...)
+		if err != nil {
+			t.Fatalf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		spec := formatter.FormatAll(taints, taintsToRemove)
+		if !reflect.DeepEqual(c.expectedSpec, spec) {
+			t.Errorf("[%s] expected spec %v, but got: %v", c.name, c.expectedSpec, spec)
+		}
+
+		parsedTaints, _, err := ParseTaintsWithOptions(spec, c.opts...)
+		if err != nil {
+			t.Errorf("[%s] expected formatted spec to parse, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(taints, sortedLike(parsedTaints, taints)) {
+			t.Errorf("[%s] expected formatted spec to parse back to %v, but got: %v", c.name, taints, parsedTaints)
+		}
+	}
+}
+
+func TestFormatTaints(t *testing.T) {
+	spec := []string{"foo=abc:NoSchedule", "bar:NoExecute", "foobar:PreferNoSchedule", "qux:NoSchedule-", "dedicated-"}
+	taints, taintsToRemove, err := ParseTaints(spec)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if formatted := FormatTaints(taints, taintsToRemove); !reflect.DeepEqual(spec, formatted) {
+		t.Errorf("expected spec %v to round-trip, but got: %v", spec, formatted)
+	}
+	if formatted := FormatTaint(v1.Taint{Key: "foobar", Effect: v1.TaintEffectNoSchedule}); formatted != "foobar:NoSchedule" {
+		t.Errorf("expected foobar:NoSchedule, but got: %v", formatted)
+	}
+	if formatted := FormatTaint(v1.Taint{Key: "foo", Value: "abc"}); formatted != "foo=abc" {
+		t.Errorf("expected foo=abc, but got: %v", formatted)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name         string
+		spec         []string
+		expectedSpec []string
+		expectedErr  bool
+	}{
+		{
+			name:         "equivalent forms",
+			spec:         []string{"foobar=:NoSchedule", "foo=abc:NoExecute", "qux=xyz:NoSchedule-", "baz=:PreferNoSchedule-", "dedicated-"},
+			expectedSpec: []string{"foobar:NoSchedule", "foo=abc:NoExecute", "qux:NoSchedule-", "baz:PreferNoSchedule-", "dedicated-"},
+		},
+		{
+			name:         "interleaved removals",
+			spec:         []string{"qux-", "foo=abc:NoSchedule", "bar=:NoExecute-", "baz:NoExecute"},
+			expectedSpec: []string{"qux-", "foo=abc:NoSchedule", "bar:NoExecute-", "baz:NoExecute"},
+		},
+		{
+			name:        "invalid spec",
+			spec:        []string{"foobar=:NoSchedule", "foo=abc"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		spec, err := Normalize(c.spec)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedSpec, spec) {
+			t.Errorf("[%s] expected spec %v, but got: %v", c.name, c.expectedSpec, spec)
+		}
+	}
+}
+
+// sortedLike reorders taints in the order of the reference, for comparing sorted output.
+func sortedLike(taints, reference []v1.Taint) []v1.Taint {
+	var ordered []v1.Taint
+	for _, ref := range reference {
+		for _, taint := range taints {
+			if taint == ref {
+				ordered = append(ordered, taint)
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,58 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// Hooks is notified of parse outcomes, allowing callers to feed their own metrics or audit
+// systems without this package depending on them. Hooks are notified once per call of Parse or
+// ParseTaintsWithOptions, for the spec list as a whole, and once per spec read by a TaintScanner.
+// ParseTaintsAll and ParseTaintsLenient take no options and never notify hooks.
+type Hooks interface {
+	// OnParse is called with the taints of a spec list, or of a scanned spec, that parsed
+	// successfully.
+	OnParse(taints, taintsToRemove []v1.Taint)
+	// OnValidateError is called with the error failing the parse of a spec list, which is that of
+	// its first invalid spec, or with the error of a scanned spec.
+	OnValidateError(err error)
+}
+
+// ApplyHooks is optionally implemented by Hooks to also be notified of taints being applied to
+// nodes by AddOrUpdateTaint and ReorganizeTaints. It is separate from Hooks so that hooks only
+// interested in parsing need not implement it.
+type ApplyHooks interface {
+	// OnApply is called with the taints added to and removed from a node once they are applied.
+	// It is not called if the node already has the taint to add.
+	OnApply(nodeName string, taints, taintsToRemove []v1.Taint)
+	// OnConflict is called with the taints to add that a node already has with the same key and
+	// effect when ReorganizeTaints is not allowed to overwrite them.
+	OnConflict(nodeName string, conflicts []v1.Taint)
+}
+
+// nopHooks is the Hooks implementation used when none is configured.
+type nopHooks struct{}
+
+func (nopHooks) OnParse(_, _ []v1.Taint) {}
+
+func (nopHooks) OnValidateError(_ error) {}
+
+func (nopHooks) OnApply(_ string, _, _ []v1.Taint) {}
+
+func (nopHooks) OnConflict(_ string, _ []v1.Taint) {}
+
+// applyHooks returns the configured hooks if they implement ApplyHooks.
+func (o *options) applyHooks() ApplyHooks {
+	if hooks, ok := o.hooks.(ApplyHooks); ok {
+		return hooks
+	}
+	return nopHooks{}
+}
+
+// WithHooks registers hooks notified of the outcome of parsing, and of applying taints if they
+// implement ApplyHooks.
+func WithHooks(hooks Hooks) Option {
+	return func(o *options) error {
+		o.hooks = hooks
+		return nil
+	}
+}
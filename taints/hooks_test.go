@@ -0,0 +1,74 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+type countingHooks struct {
+	parsed, failed int
+}
+
+func (h *countingHooks) OnParse(_, _ []v1.Taint) {
+	h.parsed++
+}
+
+func (h *countingHooks) OnValidateError(_ error) {
+	h.failed++
+}
+
+func TestWithHooks(t *testing.T) {
+	hooks := &countingHooks{}
+	for _, spec := range [][]string{{"foo=abc:NoSchedule"}, {"foo-"}, {"foo"}} {
+		_, _, _ = ParseTaintsWithOptions(spec, WithHooks(hooks))
+	}
+	if hooks.parsed != 2 {
+		t.Errorf("expected 2 parsed specs, but got: %d", hooks.parsed)
+	}
+	if hooks.failed != 1 {
+		t.Errorf("expected 1 failed spec, but got: %d", hooks.failed)
+	}
+}
+
+type applyHooks struct {
+	countingHooks
+	applied, conflicts []v1.Taint
+}
+
+func (h *applyHooks) OnApply(_ string, taints, _ []v1.Taint) {
+	h.applied = append(h.applied, taints...)
+}
+
+func (h *applyHooks) OnConflict(_ string, conflicts []v1.Taint) {
+	h.conflicts = append(h.conflicts, conflicts...)
+}
+
+func TestWithApplyHooks(t *testing.T) {
+	existing := v1.Taint{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}
+	added := v1.Taint{Key: "bar", Effect: v1.TaintEffectNoExecute}
+	node := &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{existing}}}
+
+	hooks := &applyHooks{}
+	if _, _, err := AddOrUpdateTaint(node, &added, WithHooks(hooks)); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if _, _, err := AddOrUpdateTaint(node, &existing, WithHooks(hooks)); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if _, _, err := ReorganizeTaints(node, false, []v1.Taint{added, existing}, nil, WithHooks(hooks)); err == nil {
+		t.Fatalf("expected error for conflicting taint, but got nothing")
+	}
+	if !reflect.DeepEqual([]v1.Taint{added}, hooks.applied) {
+		t.Errorf("expected applied taints %v, but got: %v", []v1.Taint{added}, hooks.applied)
+	}
+	if !reflect.DeepEqual([]v1.Taint{existing}, hooks.conflicts) {
+		t.Errorf("expected conflicts %v, but got: %v", []v1.Taint{existing}, hooks.conflicts)
+	}
+
+	// hooks without OnApply and OnConflict are still accepted
+	if _, _, err := ReorganizeTaints(node, true, []v1.Taint{added}, nil, WithHooks(&countingHooks{})); err != nil {
+		t.Errorf("expected no error, but got: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package taints
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// inventoryHeader is the header row of taint inventories.
+var inventoryHeader = []string{"node", "key", "value", "effect", "timeAdded"}
+
+// WriteCSV writes the taints of the nodes to w as comma-separated values, one row per taint
+// with the columns node, key, value, effect and timeAdded, preceded by a header row. Nodes
+// without taints get a row with only the node name, so that every node appears in the inventory.
+// TimeAdded is written in RFC 3339 format, in UTC.
+func WriteCSV(w io.Writer, nodes []v1.Node) error {
+	return writeInventory(w, nodes, ',')
+}
+
+// WriteTSV writes the same inventory as WriteCSV as tab-separated values.
+func WriteTSV(w io.Writer, nodes []v1.Node) error {
+	return writeInventory(w, nodes, '\t')
+}
+
+func writeInventory(w io.Writer, nodes []v1.Node, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(inventoryHeader); err != nil {
+		return err
+	}
+	for i := range nodes {
+		if len(nodes[i].Spec.Taints) == 0 {
+			if err := writer.Write([]string{nodes[i].Name, "", "", "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, taint := range nodes[i].Spec.Taints {
+			var timeAdded string
+			if taint.TimeAdded != nil {
+				timeAdded = taint.TimeAdded.UTC().Format(time.RFC3339)
+			}
+			if err := writer.Write([]string{nodes[i].Name, taint.Key, taint.Value, string(taint.Effect), timeAdded}); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,53 @@
+package taints
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteInventory(t *testing.T) {
+	timeAdded := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	nodes := []v1.Node{
+		newNode("worker-1",
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: v1.TaintNodeNotReady, Effect: v1.TaintEffectNoExecute, TimeAdded: &timeAdded}),
+		newNode("worker-2"),
+	}
+
+	cases := []struct {
+		name     string
+		write    func(*bytes.Buffer) error
+		expected string
+	}{
+		{
+			name:  "csv",
+			write: func(b *bytes.Buffer) error { return WriteCSV(b, nodes) },
+			expected: "node,key,value,effect,timeAdded\n" +
+				"worker-1,dedicated,gpu,NoSchedule,\n" +
+				"worker-1,node.kubernetes.io/not-ready,,NoExecute,2024-01-01T12:00:00Z\n" +
+				"worker-2,,,,\n",
+		},
+		{
+			name:  "tsv",
+			write: func(b *bytes.Buffer) error { return WriteTSV(b, nodes) },
+			expected: "node\tkey\tvalue\teffect\ttimeAdded\n" +
+				"worker-1\tdedicated\tgpu\tNoSchedule\t\n" +
+				"worker-1\tnode.kubernetes.io/not-ready\t\tNoExecute\t2024-01-01T12:00:00Z\n" +
+				"worker-2\t\t\t\t\n",
+		},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		if err := c.write(&out); err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if out.String() != c.expected {
+			t.Errorf("[%s] expected\n%s\nbut got:\n%s", c.name, c.expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package taints
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// StrategicMergePatch returns a YAML strategic merge patch, as used by kustomize
+// patchesStrategicMerge, setting the taints of the named node. Node taints have no merge key, so
+// the patch lists all taints the node should have, and a node without taints is patched with
+// `taints: null`.
+func StrategicMergePatch(nodeName string, taints []v1.Taint) ([]byte, error) {
+	var patchTaints interface{}
+	if len(taints) > 0 {
+		patchTaints = taints
+	}
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Node",
+		"metadata": map[string]interface{}{
+			"name": nodeName,
+		},
+		"spec": map[string]interface{}{
+			"taints": patchTaints,
+		},
+	})
+}
+
+// WriteStrategicMergePatches writes a StrategicMergePatch per node into dir, named
+// `<node>.yaml`, and returns the names of the written files in node name order.
+func WriteStrategicMergePatches(dir string, taints map[string][]v1.Taint) ([]string, error) {
+	names := make([]string, 0, len(taints))
+	for name := range taints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		patch, err := StrategicMergePatch(name, taints[name])
+		if err != nil {
+			return nil, err
+		}
+		file := name + ".yaml"
+		if err := os.WriteFile(filepath.Join(dir, file), patch, 0o644); err != nil {
+			return nil, fmt.Errorf("writing patch for node %v: %v", name, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,53 @@
+package taints
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWriteStrategicMergePatches(t *testing.T) {
+	dir := t.TempDir()
+	files, err := WriteStrategicMergePatches(dir, map[string][]v1.Taint{
+		"worker-2": nil,
+		"worker-1": {{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if expected := []string{"worker-1.yaml", "worker-2.yaml"}; !reflect.DeepEqual(expected, files) {
+		t.Errorf("expected files %v, but got: %v", expected, files)
+	}
+
+	expected := map[string]string{
+		"worker-1.yaml": `apiVersion: v1
+kind: Node
+metadata:
+  name: worker-1
+spec:
+  taints:
+  - effect: NoSchedule
+    key: dedicated
+    value: gpu
+`,
+		"worker-2.yaml": `apiVersion: v1
+kind: Node
+metadata:
+  name: worker-2
+spec:
+  taints: null
+`,
+	}
+	for file, content := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("expected file %v to be written, but got: %v", file, err)
+		}
+		if string(data) != content {
+			t.Errorf("expected %v to contain\n%s\nbut got:\n%s", file, content, data)
+		}
+	}
+}
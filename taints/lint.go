@@ -0,0 +1,142 @@
+package taints
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Severity ranks how serious a finding is.
+type Severity string
+
+const (
+	// SeverityError marks a spec that cannot be used.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a spec that is valid but likely not what was intended.
+	SeverityWarning Severity = "warning"
+)
+
+// Lint rules reported in LintFinding.Rule.
+const (
+	RuleInvalidSpec    = "invalid-spec"
+	RuleDuplicateTaint = "duplicate-taint"
+	RuleEmptyValue     = "empty-value"
+	RuleRemovalValue   = "removal-value"
+	RuleAddAndRemove   = "add-and-remove"
+	RuleUnprefixedKey  = "unprefixed-key"
+	RuleBooleanValue   = "boolean-value"
+	RulePolicy         = "policy-violation"
+)
+
+// LintFinding describes a problem with a single spec.
+type LintFinding struct {
+	// Index is the position of the spec in the linted list.
+	Index    int      `json:"index"`
+	Spec     string   `json:"spec"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Line is the line of the spec in its file, starting at 1, for findings of LintTaintsFile.
+	Line int `json:"line,omitempty"`
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%d: %v: %v (%v)", f.Index, f.Severity, f.Message, f.Rule)
+}
+
+// nonCanonicalBooleans are values that read as booleans but differ from "true" and "false",
+// which tolerations must match exactly.
+var nonCanonicalBooleans = map[string]bool{
+	"yes": true, "no": true, "on": true, "off": true, "y": true, "n": true,
+}
+
+// Lint checks each spec for errors, violations of the given policies, and legal but likely
+// unintended forms, and returns the findings ordered by spec index.
+func Lint(spec []string, policies ...Policy) []LintFinding {
+	var findings []LintFinding
+	report := func(i int, rule string, severity Severity, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{
+			Index:    i,
+			Spec:     spec[i],
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	added := map[string]bool{}
+	removed := map[string]bool{}
+	addedTaints := map[v1.Taint]bool{}
+	for i, taintSpec := range spec {
+		taints, taintsToRemove, err := ParseTaints([]string{taintSpec})
+		if err != nil {
+			report(i, RuleInvalidSpec, SeverityError, "%v", err)
+			continue
+		}
+
+		keyValue := strings.SplitN(strings.TrimSuffix(taintSpec, "-"), ":", 2)[0]
+		if len(taintsToRemove) > 0 {
+			taint := taintsToRemove[0]
+			if strings.Contains(keyValue, "=") {
+				report(i, RuleRemovalValue, SeverityWarning, "the value of removal %v is ignored, taints are removed by key and effect", taintSpec)
+			}
+			if added[taint.Key] {
+				report(i, RuleAddAndRemove, SeverityWarning, "key %v is both added and removed", taint.Key)
+			}
+			removed[taint.Key] = true
+			continue
+		}
+
+		taint := taints[0]
+		for _, policy := range policies {
+			if err := policy.CheckAdd(taint); err != nil {
+				report(i, RulePolicy, SeverityError, "%v", err)
+			}
+		}
+		if addedTaints[v1.Taint{Key: taint.Key, Effect: taint.Effect}] {
+			report(i, RuleDuplicateTaint, SeverityError, "duplicated taints with the same key and effect: %v", taint.ToString())
+		}
+		addedTaints[v1.Taint{Key: taint.Key, Effect: taint.Effect}] = true
+		if removed[taint.Key] {
+			report(i, RuleAddAndRemove, SeverityWarning, "key %v is both added and removed", taint.Key)
+		}
+		added[taint.Key] = true
+
+		if strings.HasSuffix(keyValue, "=") {
+			report(i, RuleEmptyValue, SeverityWarning, "empty value, write %v instead", taint.ToString())
+		}
+		if !strings.Contains(taint.Key, "/") {
+			report(i, RuleUnprefixedKey, SeverityWarning, "key %v has no prefix, use a domain prefix such as example.com/%v to avoid collisions", taint.Key, taint.Key)
+		}
+		lower := strings.ToLower(taint.Value)
+		if ((lower == "true" || lower == "false") && lower != taint.Value) || nonCanonicalBooleans[lower] {
+			report(i, RuleBooleanValue, SeverityWarning, "value %v looks like a boolean, tolerations must match it exactly", taint.Value)
+		}
+	}
+	return findings
+}
+
+// LintTaintsFile lints the specs of a taints file, as read by ParseTaintsFile, setting the line of
+// each finding.
+func LintTaintsFile(r io.Reader, policies ...Policy) ([]LintFinding, error) {
+	var spec []string
+	var lines []int
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if taintSpec := stripComment(scanner.Text()); len(taintSpec) > 0 {
+			spec = append(spec, taintSpec)
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	findings := Lint(spec, policies...)
+	for i := range findings {
+		findings[i].Line = lines[findings[i].Index]
+	}
+	return findings, nil
+}
@@ -0,0 +1,71 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name          string
+		spec          []string
+		policies      []Policy
+		expectedRules []string
+	}{
+		{
+			name: "clean specs",
+			spec: []string{"example.com/foo=true:NoSchedule", "example.com/bar:NoExecute", "example.com/baz-"},
+		},
+		{
+			name:          "invalid spec",
+			spec:          []string{"example.com/foo"},
+			expectedRules: []string{RuleInvalidSpec},
+		},
+		{
+			name:          "duplicated taints",
+			spec:          []string{"example.com/foo=a:NoSchedule", "example.com/foo=b:NoSchedule"},
+			expectedRules: []string{RuleDuplicateTaint},
+		},
+		{
+			name:          "empty value",
+			spec:          []string{"example.com/foo=:NoSchedule"},
+			expectedRules: []string{RuleEmptyValue},
+		},
+		{
+			name:          "value in removal",
+			spec:          []string{"example.com/foo=abc:NoSchedule-"},
+			expectedRules: []string{RuleRemovalValue},
+		},
+		{
+			name:          "key added and removed",
+			spec:          []string{"example.com/foo-", "example.com/foo:NoSchedule", "example.com/bar:NoSchedule", "example.com/bar:NoExecute-"},
+			expectedRules: []string{RuleAddAndRemove, RuleAddAndRemove},
+		},
+		{
+			name:          "unprefixed key",
+			spec:          []string{"foo:NoSchedule"},
+			expectedRules: []string{RuleUnprefixedKey},
+		},
+		{
+			name:          "boolean-like values",
+			spec:          []string{"example.com/foo=True:NoSchedule", "example.com/bar=yes:NoSchedule", "example.com/baz=false:NoSchedule"},
+			expectedRules: []string{RuleBooleanValue, RuleBooleanValue},
+		},
+		{
+			name:          "policy violation",
+			spec:          []string{"example.com/foo:NoExecute", "example.com/bar:NoExecute"},
+			policies:      []Policy{NoExecuteAllowList("example.com/foo")},
+			expectedRules: []string{RulePolicy},
+		},
+	}
+
+	for _, c := range cases {
+		var rules []string
+		for _, finding := range Lint(c.spec, c.policies...) {
+			rules = append(rules, finding.Rule)
+		}
+		if !reflect.DeepEqual(c.expectedRules, rules) {
+			t.Errorf("[%s] expected rules %v, but got: %v", c.name, c.expectedRules, rules)
+		}
+	}
+}
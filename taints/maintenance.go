@@ -0,0 +1,332 @@
+package taints
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MaintenanceWindow applies taints to the nodes matching a label selector during a recurring
+// window of time.
+type MaintenanceWindow struct {
+	// Name identifies the window in conflicts and errors.
+	Name string `json:"name"`
+	// Schedule is a cron expression with the five fields minute, hour, day of month, month and
+	// day of week, such as '0 2 * * 6' for every Saturday at 02:00, at which the window opens.
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open.
+	Duration time.Duration `json:"duration"`
+	// Selector is the label selector of the nodes of the window. An empty selector matches all
+	// nodes.
+	Selector string `json:"selector,omitempty"`
+	// Taints are the specs of the taints applied while the window is open, such as
+	// 'example.com/maintenance:NoSchedule'.
+	Taints []string `json:"taints"`
+}
+
+// maintenanceWindow is a MaintenanceWindow with its schedule, selector and taints parsed.
+type maintenanceWindow struct {
+	MaintenanceWindow
+	schedule *cronSchedule
+	selector labels.Selector
+	taints   []v1.Taint
+}
+
+// MaintenanceScheduler computes the taints of nodes from recurring maintenance windows.
+type MaintenanceScheduler struct {
+	windows []maintenanceWindow
+}
+
+// NewMaintenanceScheduler returns a MaintenanceScheduler for the windows. It is an error for a
+// schedule, selector or taint spec to be invalid, or for a window not to have a duration.
+func NewMaintenanceScheduler(windows []MaintenanceWindow) (*MaintenanceScheduler, error) {
+	scheduler := &MaintenanceScheduler{}
+	for _, window := range windows {
+		if window.Duration <= 0 {
+			return nil, fmt.Errorf("invalid maintenance window %v: duration must be positive", window.Name)
+		}
+		schedule, err := parseCronSchedule(window.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %v: %v", window.Name, err)
+		}
+		selector, err := labels.Parse(window.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %v: %v", window.Name, err)
+		}
+		taints, taintsToRemove, err := ParseTaints(window.Taints)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %v: %v", window.Name, err)
+		}
+		if len(taintsToRemove) > 0 {
+			return nil, fmt.Errorf("invalid maintenance window %v: taints must not be removed", window.Name)
+		}
+		scheduler.windows = append(scheduler.windows, maintenanceWindow{
+			MaintenanceWindow: window,
+			schedule:          schedule,
+			selector:          selector,
+			taints:            taints,
+		})
+	}
+	return scheduler, nil
+}
+
+// Evaluate returns the changes bringing the taints of the node in line with the windows open at
+// the given time. Taints of open windows selecting the node are added, and taints of other
+// windows are removed. Taints not managed by any window are left alone.
+func (s *MaintenanceScheduler) Evaluate(node *v1.Node, now time.Time) TaintDiff {
+	var desired []v1.Taint
+	for _, window := range s.windows {
+		if !window.selector.Matches(labels.Set(node.Labels)) || !window.openAt(now) {
+			continue
+		}
+		for _, taint := range window.taints {
+			if indexOfTaint(desired, &taint) >= 0 {
+				continue
+			}
+			if i := indexOfTaint(node.Spec.Taints, &taint); i >= 0 && node.Spec.Taints[i].Value == taint.Value {
+				taint = node.Spec.Taints[i]
+			}
+			desired = append(desired, taint)
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if !s.manages(&taint) {
+			desired = append(desired, taint)
+		}
+	}
+	return DiffTaints(node.Spec.Taints, desired)
+}
+
+// manages reports whether a window of the scheduler applies the taint.
+func (s *MaintenanceScheduler) manages(taint *v1.Taint) bool {
+	for i := range s.windows {
+		if indexOfTaint(s.windows[i].taints, taint) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// openAt reports whether the window opened at most its duration before the given time.
+func (w *maintenanceWindow) openAt(now time.Time) bool {
+	_, ok := w.schedule.latest(now, now.Add(-w.Duration))
+	return ok
+}
+
+// occurrences returns the intervals of the window that are open within [from, to).
+func (w *maintenanceWindow) occurrences(from, to time.Time) [][2]time.Time {
+	var intervals [][2]time.Time
+	for start := from.Add(-w.Duration).Truncate(time.Minute); start.Before(to); start = start.Add(time.Minute) {
+		if end := start.Add(w.Duration); end.After(from) && w.schedule.matches(start) {
+			intervals = append(intervals, [2]time.Time{start, end})
+		}
+	}
+	return intervals
+}
+
+// MaintenanceConflict is a time at which two windows selecting the same node are open together.
+type MaintenanceConflict struct {
+	Node    string    `json:"node"`
+	Windows [2]string `json:"windows"`
+	At      time.Time `json:"at"`
+}
+
+// Conflicts returns, for every node and every pair of windows selecting it, the first time within
+// the horizon after from at which both windows are open.
+func (s *MaintenanceScheduler) Conflicts(nodes []v1.Node, from time.Time, horizon time.Duration) []MaintenanceConflict {
+	to := from.Add(horizon)
+	occurrences := make([][][2]time.Time, len(s.windows))
+	for i := range s.windows {
+		occurrences[i] = s.windows[i].occurrences(from, to)
+	}
+
+	var conflicts []MaintenanceConflict
+	for _, node := range nodes {
+		set := labels.Set(node.Labels)
+		for i := range s.windows {
+			if !s.windows[i].selector.Matches(set) {
+				continue
+			}
+			for j := i + 1; j < len(s.windows); j++ {
+				if !s.windows[j].selector.Matches(set) {
+					continue
+				}
+				if at, ok := firstOverlap(occurrences[i], occurrences[j], from); ok {
+					conflicts = append(conflicts, MaintenanceConflict{
+						Node:    node.Name,
+						Windows: [2]string{s.windows[i].Name, s.windows[j].Name},
+						At:      at,
+					})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// firstOverlap returns the earliest time, not before from, at which intervals of both lists
+// overlap.
+func firstOverlap(a, b [][2]time.Time, from time.Time) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for _, x := range a {
+		for _, y := range b {
+			start, end := x[0], x[1]
+			if y[0].After(start) {
+				start = y[0]
+			}
+			if y[1].Before(end) {
+				end = y[1]
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if start.Before(end) && (!found || start.Before(first)) {
+				first, found = start, true
+			}
+		}
+	}
+	return first, found
+}
+
+// cronSchedule holds the allowed values of each field of a cron expression.
+type cronSchedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek map[int]bool
+	// anyDayOfMonth and anyDayOfWeek are set for fields written as '*' or '*/1'. Like in cron, a
+	// time matches days of month and week that are both written otherwise if it matches either of
+	// them, even if they list every day such as '1-31'.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// cronFields are the names and value ranges of the fields of a cron expression.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 7 is Sunday, like 0
+	{"day of week", 0, 7},
+}
+
+// parseCronSchedule parses a cron expression with five fields, each being '*' or a comma
+// separated list of values and ranges, optionally with a step, such as '1-5' or '*/15'.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected %d fields", expr, len(cronFields))
+	}
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		var err error
+		if values[i], err = parseCronField(field, cronFields[i].min, cronFields[i].max); err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: invalid %v: %v", expr, cronFields[i].name, err)
+		}
+	}
+	if values[4][7] {
+		delete(values[4], 7)
+		values[4][0] = true
+	}
+	return &cronSchedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: isCronWildcard(fields[2]),
+		anyDayOfWeek:  isCronWildcard(fields[4]),
+	}, nil
+}
+
+// isCronWildcard reports whether the field is written as '*', optionally with a step of 1.
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "*/1"
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(part, "/"); ok {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step: %v", stepPart)
+			}
+			part = rangePart
+		}
+		low, high := min, max
+		if part != "*" {
+			lowPart, highPart, isRange := strings.Cut(part, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return nil, fmt.Errorf("invalid value: %v", lowPart)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return nil, fmt.Errorf("invalid value: %v", highPart)
+				}
+			}
+		}
+		if low < min || high > max || low > high {
+			return nil, fmt.Errorf("value out of range [%d, %d]: %v", min, max, part)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// matches reports whether the minute of the time is scheduled.
+func (c *cronSchedule) matches(t time.Time) bool {
+	return c.minutes[t.Minute()] && c.hours[t.Hour()] && c.matchesDay(t)
+}
+
+// matchesDay reports whether the day of the time is scheduled.
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	if !c.months[int(t.Month())] {
+		return false
+	}
+	dayOfMonth, dayOfWeek := c.daysOfMonth[t.Day()], c.daysOfWeek[int(t.Weekday())]
+	switch {
+	case c.anyDayOfMonth || c.anyDayOfWeek:
+		return dayOfMonth && dayOfWeek
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// latest returns the latest scheduled minute at or before t and after since. Days and hours that
+// are not scheduled are skipped whole, so long windows don't cost a step per minute.
+func (c *cronSchedule) latest(t, since time.Time) (time.Time, bool) {
+	for t = t.Truncate(time.Minute); t.After(since); {
+		year, month, day := t.Date()
+		previous := t.Add(-time.Minute)
+		switch {
+		case !c.matchesDay(t):
+			previous = time.Date(year, month, day, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !c.hours[t.Hour()]:
+			previous = time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		case c.minutes[t.Minute()]:
+			return t, true
+		}
+		// the start of a day or hour is ambiguous around daylight saving time changes
+		t = minTime(previous, t.Add(-time.Minute))
+	}
+	return time.Time{}, false
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
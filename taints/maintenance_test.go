@@ -0,0 +1,165 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	cases := []struct {
+		name        string
+		schedule    string
+		time        time.Time
+		expected    bool
+		expectedErr bool
+	}{
+		{name: "every minute", schedule: "* * * * *", time: time.Date(2024, 1, 1, 12, 34, 0, 0, time.UTC), expected: true},
+		{name: "step", schedule: "*/15 * * * *", time: time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC), expected: true},
+		{name: "step mismatch", schedule: "*/15 * * * *", time: time.Date(2024, 1, 1, 12, 40, 0, 0, time.UTC)},
+		{name: "saturday", schedule: "0 2 * * 6", time: time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "weekday range mismatch", schedule: "0 2 * * 1-5", time: time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC)},
+		{name: "day of month or week", schedule: "0 2 15 * 1", time: time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "sunday as 7", schedule: "0 2 * * 7", time: time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "weekend range through 7", schedule: "0 2 * * 6-7", time: time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "unrestricted day of week step", schedule: "0 2 15 * */1", time: time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{name: "restricted day of week step", schedule: "0 2 15 * */2", time: time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "every day of month range or week", schedule: "0 2 1-31 * 1", time: time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "list", schedule: "0 2,14 * * *", time: time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), expected: true},
+		{name: "too few fields", schedule: "0 2 * *", expectedErr: true},
+		{name: "out of range", schedule: "0 24 * * *", expectedErr: true},
+		{name: "invalid step", schedule: "*/0 * * * *", expectedErr: true},
+		{name: "day of week out of range", schedule: "0 2 * * 8", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		schedule, err := parseCronSchedule(c.schedule)
+		if c.expectedErr {
+			if err == nil {
+				t.Errorf("[%s] expected error, but got nothing", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+			continue
+		}
+		if matches := schedule.matches(c.time); matches != c.expected {
+			t.Errorf("[%s] expected match %v, but got: %v", c.name, c.expected, matches)
+		}
+	}
+}
+
+func TestMaintenanceSchedulerEvaluate(t *testing.T) {
+	scheduler, err := NewMaintenanceScheduler([]MaintenanceWindow{{
+		Name:     "gpu-weekly",
+		Schedule: "0 2 * * 6",
+		Duration: 4 * time.Hour,
+		Selector: "pool=gpu",
+		Taints:   []string{"example.com/maintenance:NoSchedule"},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	maintenance := v1.Taint{Key: "example.com/maintenance", Effect: v1.TaintEffectNoSchedule}
+	unrelated := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	gpuNode := newNode("gpu-1", unrelated)
+	gpuNode.Labels = map[string]string{"pool": "gpu"}
+	tainted := newNode("gpu-2", unrelated, maintenance)
+	tainted.Labels = map[string]string{"pool": "gpu"}
+	cpuNode := newNode("cpu-1")
+
+	saturday := time.Date(2024, 1, 6, 3, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name            string
+		node            v1.Node
+		now             time.Time
+		expectedAdded   []v1.Taint
+		expectedRemoved []v1.Taint
+	}{
+		{name: "window open", node: gpuNode, now: saturday, expectedAdded: []v1.Taint{maintenance}},
+		{name: "window open and tainted", node: tainted, now: saturday},
+		{name: "window closed", node: tainted, now: saturday.Add(3 * time.Hour), expectedRemoved: []v1.Taint{maintenance}},
+		{name: "node not selected", node: cpuNode, now: saturday},
+	}
+	for _, c := range cases {
+		diff := scheduler.Evaluate(&c.node, c.now)
+		if !reflect.DeepEqual(c.expectedAdded, diff.Added) {
+			t.Errorf("[%s] expected added taints %v, but got: %v", c.name, c.expectedAdded, diff.Added)
+		}
+		if !reflect.DeepEqual(c.expectedRemoved, diff.Removed) {
+			t.Errorf("[%s] expected removed taints %v, but got: %v", c.name, c.expectedRemoved, diff.Removed)
+		}
+	}
+}
+
+func TestMaintenanceWindowOpenAt(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		now      time.Time
+		expected bool
+	}{
+		{name: "monthly window open", schedule: "0 2 1 * *", duration: 30 * 24 * time.Hour, now: time.Date(2024, 1, 25, 12, 0, 0, 0, time.UTC), expected: true},
+		{name: "monthly window closing", schedule: "0 2 1 * *", duration: 30 * 24 * time.Hour, now: time.Date(2024, 1, 31, 1, 59, 0, 0, time.UTC), expected: true},
+		{name: "monthly window closed", schedule: "0 2 1 * *", duration: 30 * 24 * time.Hour, now: time.Date(2024, 1, 31, 2, 0, 0, 0, time.UTC)},
+		{name: "monthly window not yet open", schedule: "0 2 1 * *", duration: 30 * 24 * time.Hour, now: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{name: "hourly step open", schedule: "30 */6 * * *", duration: time.Hour, now: time.Date(2024, 1, 1, 7, 29, 0, 0, time.UTC), expected: true},
+		{name: "hourly step closed", schedule: "30 */6 * * *", duration: time.Hour, now: time.Date(2024, 1, 1, 7, 31, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		schedule, err := parseCronSchedule(c.schedule)
+		if err != nil {
+			t.Fatalf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		window := maintenanceWindow{MaintenanceWindow: MaintenanceWindow{Duration: c.duration}, schedule: schedule}
+		if open := window.openAt(c.now); open != c.expected {
+			t.Errorf("[%s] expected open %v, but got: %v", c.name, c.expected, open)
+		}
+	}
+}
+
+func TestMaintenanceSchedulerConflicts(t *testing.T) {
+	scheduler, err := NewMaintenanceScheduler([]MaintenanceWindow{
+		{Name: "gpu-weekly", Schedule: "0 2 * * 6", Duration: 4 * time.Hour, Selector: "pool=gpu", Taints: []string{"example.com/maintenance:NoSchedule"}},
+		{Name: "kernel", Schedule: "0 5 * * *", Duration: time.Hour, Taints: []string{"example.com/kernel-upgrade:NoExecute"}},
+		{Name: "cpu-weekly", Schedule: "0 2 * * 0", Duration: 4 * time.Hour, Selector: "pool=cpu", Taints: []string{"example.com/maintenance:NoSchedule"}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	gpuNode := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-1", Labels: map[string]string{"pool": "gpu"}}}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	conflicts := scheduler.Conflicts([]v1.Node{gpuNode}, from, 7*24*time.Hour)
+	expected := []MaintenanceConflict{{
+		Node:    "gpu-1",
+		Windows: [2]string{"gpu-weekly", "kernel"},
+		At:      time.Date(2024, 1, 6, 5, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(expected, conflicts) {
+		t.Errorf("expected conflicts %+v, but got: %+v", expected, conflicts)
+	}
+}
+
+func TestNewMaintenanceSchedulerErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		window MaintenanceWindow
+	}{
+		{name: "no duration", window: MaintenanceWindow{Schedule: "* * * * *", Taints: []string{"foo:NoSchedule"}}},
+		{name: "invalid schedule", window: MaintenanceWindow{Schedule: "daily", Duration: time.Hour, Taints: []string{"foo:NoSchedule"}}},
+		{name: "invalid selector", window: MaintenanceWindow{Schedule: "* * * * *", Duration: time.Hour, Selector: "pool in (gpu", Taints: []string{"foo:NoSchedule"}}},
+		{name: "removal", window: MaintenanceWindow{Schedule: "* * * * *", Duration: time.Hour, Taints: []string{"foo-"}}},
+	}
+	for _, c := range cases {
+		if _, err := NewMaintenanceScheduler([]MaintenanceWindow{c.window}); err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+	}
+}
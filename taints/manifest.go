@@ -0,0 +1,109 @@
+package taints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestList holds the items of a List manifest, such as a NodeList.
+type manifestList struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+// ReadTaintsFromManifest reads YAML or JSON manifests, possibly several YAML documents, and
+// returns the taints of every Node they contain by node name. Nodes are also read from the items
+// of List and NodeList manifests, such as the output of `kubectl get nodes -o yaml`. Manifests of
+// other kinds are ignored.
+func ReadTaintsFromManifest(r io.Reader) (map[string][]v1.Taint, error) {
+	taints := map[string][]v1.Taint{}
+	err := walkManifest(r, func(kind string, raw json.RawMessage) error {
+		if kind != "Node" {
+			return nil
+		}
+		var node v1.Node
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return fmt.Errorf("invalid node manifest: %v", err)
+		}
+		if _, ok := taints[node.Name]; ok {
+			return fmt.Errorf("invalid manifest: node %v is defined more than once", node.Name)
+		}
+		taints[node.Name] = node.Spec.Taints
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return taints, nil
+}
+
+// ReadNodesFromManifest reads the Nodes of YAML or JSON manifests in the order they appear, such
+// as the output of `kubectl get nodes -o json` piped on stdin, for use with ComputeStats,
+// GroupNodesByTaintSignature or WriteCSV without calling the API server. Manifests of other kinds
+// are ignored.
+func ReadNodesFromManifest(r io.Reader) ([]v1.Node, error) {
+	var nodes []v1.Node
+	err := walkManifest(r, func(kind string, raw json.RawMessage) error {
+		if kind != "Node" {
+			return nil
+		}
+		var node v1.Node
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return fmt.Errorf("invalid node manifest: %v", err)
+		}
+		nodes = append(nodes, node)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// walkManifest calls visit with the kind and content of every object in the YAML or JSON
+// manifests read from r, descending into the items of lists.
+func walkManifest(r io.Reader, visit func(kind string, raw json.RawMessage) error) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid manifest: %v", err)
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := walkManifestObject(raw, visit); err != nil {
+			return err
+		}
+	}
+}
+
+func walkManifestObject(raw json.RawMessage, visit func(kind string, raw json.RawMessage) error) error {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return fmt.Errorf("invalid manifest: %v", err)
+	}
+	if !strings.HasSuffix(typeMeta.Kind, "List") {
+		return visit(typeMeta.Kind, raw)
+	}
+
+	var list manifestList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return fmt.Errorf("invalid list manifest: %v", err)
+	}
+	for _, item := range list.Items {
+		if err := walkManifestObject(item, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package taints
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+)
+
+// PatchManifestTaints copies the YAML manifests read from r to w, replacing the spec.taints of
+// every Node named in taints with the given taints. Comments are preserved, including those of
+// taint entries whose key and effect are kept, but indentation is normalized to two spaces. An
+// empty list of taints removes spec.taints. It is an error for a named node not to be found.
+func PatchManifestTaints(r io.Reader, w io.Writer, taints map[string][]v1.Taint) error {
+	var docs []*yaml.Node
+	decoder := yaml.NewDecoder(r)
+	for {
+		doc := &yaml.Node{}
+		if err := decoder.Decode(doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("invalid manifest: %v", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	patched := map[string]bool{}
+	for _, doc := range docs {
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		root := doc.Content[0]
+		if kind := mappingValue(root, "kind"); kind == nil || kind.Value != "Node" {
+			continue
+		}
+		name := mappingValue(mappingValue(root, "metadata"), "name")
+		if name == nil {
+			continue
+		}
+		nodeTaints, ok := taints[name.Value]
+		if !ok {
+			continue
+		}
+		patchTaintsNode(root, nodeTaints)
+		patched[name.Value] = true
+	}
+
+	var missing []string
+	for name := range taints {
+		if !patched[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("nodes not found in manifest: %v", strings.Join(missing, ", "))
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	for _, doc := range docs {
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return encoder.Close()
+}
+
+// patchTaintsNode replaces spec.taints of the node manifest, reusing the entries of taints that
+// are kept so that their comments survive.
+func patchTaintsNode(root *yaml.Node, taints []v1.Taint) {
+	spec := mappingValue(root, "spec")
+	if spec == nil {
+		if len(taints) == 0 {
+			return
+		}
+		spec = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(root, "spec", spec)
+	}
+	if len(taints) == 0 {
+		deleteMappingValue(spec, "taints")
+		return
+	}
+
+	existing := mappingValue(spec, "taints")
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for i := range taints {
+		entry := matchingTaintEntry(existing, &taints[i])
+		if entry == nil {
+			entry = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		setTaintEntry(entry, &taints[i])
+		sequence.Content = append(sequence.Content, entry)
+	}
+	if existing != nil {
+		sequence.HeadComment = existing.HeadComment
+		sequence.LineComment = existing.LineComment
+		sequence.FootComment = existing.FootComment
+	}
+	setMappingValue(spec, "taints", sequence)
+}
+
+// matchingTaintEntry returns the entry of the taints sequence with the key and effect of the
+// taint, or nil.
+func matchingTaintEntry(sequence *yaml.Node, taint *v1.Taint) *yaml.Node {
+	if sequence == nil || sequence.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, entry := range sequence.Content {
+		key, effect := mappingValue(entry, "key"), mappingValue(entry, "effect")
+		if key != nil && effect != nil && key.Value == taint.Key && effect.Value == string(taint.Effect) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func setTaintEntry(entry *yaml.Node, taint *v1.Taint) {
+	setMappingScalar(entry, "key", taint.Key)
+	if len(taint.Value) > 0 {
+		setMappingScalar(entry, "value", taint.Value)
+	} else {
+		deleteMappingValue(entry, "value")
+	}
+	setMappingScalar(entry, "effect", string(taint.Effect))
+	if taint.TimeAdded != nil {
+		setMappingScalar(entry, "timeAdded", taint.TimeAdded.UTC().Format(time.RFC3339))
+	} else {
+		deleteMappingValue(entry, "timeAdded")
+	}
+}
+
+// mappingValue returns the value of the key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setMappingScalar sets a string value, keeping the existing value node and its comments.
+func setMappingScalar(mapping *yaml.Node, key, value string) {
+	if existing := mappingValue(mapping, key); existing != nil && existing.Kind == yaml.ScalarNode {
+		existing.Value = value
+		existing.Tag = "!!str"
+		return
+	}
+	setMappingValue(mapping, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+func deleteMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
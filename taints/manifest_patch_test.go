@@ -0,0 +1,123 @@
+package taints
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPatchManifestTaints(t *testing.T) {
+	cases := []struct {
+		name        string
+		manifest    string
+		taints      map[string][]v1.Taint
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name: "replace taints keeping comments",
+			manifest: `# worker pool
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-1
+spec:
+  # reviewed by the platform team
+  taints:
+    # GPU nodes only
+    - key: dedicated
+      value: gpu
+      effect: NoSchedule
+    - key: obsolete
+      effect: NoExecute
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-2 # untouched
+`,
+			taints: map[string][]v1.Taint{
+				"worker-1": {
+					{Key: "dedicated", Value: "ml", Effect: v1.TaintEffectNoSchedule},
+					{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+				},
+			},
+			expected: `# worker pool
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-1
+spec:
+  # reviewed by the platform team
+  taints:
+    # GPU nodes only
+    - key: dedicated
+      value: ml
+      effect: NoSchedule
+    - key: spot
+      effect: PreferNoSchedule
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-2 # untouched
+`,
+		},
+		{
+			name: "add and remove spec.taints",
+			manifest: `kind: Node
+metadata:
+  name: worker-1
+---
+kind: Node
+metadata:
+  name: worker-2
+spec:
+  unschedulable: true
+  taints:
+    - key: foo
+      effect: NoSchedule
+`,
+			taints: map[string][]v1.Taint{
+				"worker-1": {{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+				"worker-2": nil,
+			},
+			expected: `kind: Node
+metadata:
+  name: worker-1
+spec:
+  taints:
+    - key: foo
+      effect: NoSchedule
+---
+kind: Node
+metadata:
+  name: worker-2
+spec:
+  unschedulable: true
+`,
+		},
+		{
+			name:        "node not in manifest",
+			manifest:    "kind: Node\nmetadata:\n  name: worker-1\n",
+			taints:      map[string][]v1.Taint{"worker-2": nil},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		err := PatchManifestTaints(strings.NewReader(c.manifest), &out, c.taints)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !c.expectedErr && out.String() != c.expected {
+			t.Errorf("[%s] expected manifest\n%s\nbut got:\n%s", c.name, c.expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package taints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestReadTaintsFromManifest(t *testing.T) {
+	cases := []struct {
+		name           string
+		manifest       string
+		expectedTaints map[string][]v1.Taint
+		expectedErr    bool
+	}{
+		{
+			name:           "empty manifest",
+			manifest:       "",
+			expectedTaints: map[string][]v1.Taint{},
+		},
+		{
+			name: "yaml documents",
+			manifest: `
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-1
+spec:
+  taints:
+  - key: dedicated
+    value: gpu
+    effect: NoSchedule
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-2
+`,
+			expectedTaints: map[string][]v1.Taint{
+				"worker-1": {{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+				"worker-2": nil,
+			},
+		},
+		{
+			name: "json list",
+			manifest: `{"apiVersion":"v1","kind":"List","items":[
+				{"apiVersion":"v1","kind":"Node","metadata":{"name":"worker-1"},"spec":{"taints":[{"key":"foo","effect":"NoExecute"}]}}
+			]}`,
+			expectedTaints: map[string][]v1.Taint{
+				"worker-1": {{Key: "foo", Effect: v1.TaintEffectNoExecute}},
+			},
+		},
+		{
+			name: "node defined twice",
+			manifest: `
+kind: Node
+metadata:
+  name: worker-1
+---
+kind: Node
+metadata:
+  name: worker-1
+`,
+			expectedErr: true,
+		},
+		{
+			name:        "malformed manifest",
+			manifest:    "kind: Node\nspec: [",
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, err := ReadTaintsFromManifest(strings.NewReader(c.manifest))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+	}
+}
+
+func TestReadNodesFromManifest(t *testing.T) {
+	kubectlOutput := `{
+    "apiVersion": "v1",
+    "items": [
+        {"apiVersion": "v1", "kind": "Node", "metadata": {"name": "worker-2"}, "spec": {"taints": [{"key": "dedicated", "value": "gpu", "effect": "NoSchedule"}]}},
+        {"apiVersion": "v1", "kind": "Node", "metadata": {"name": "worker-1"}, "spec": {}}
+    ],
+    "kind": "List",
+    "metadata": {"resourceVersion": ""}
+}`
+	nodes, err := ReadNodesFromManifest(strings.NewReader(kubectlOutput))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expected := []v1.Node{
+		newNode("worker-2", v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}),
+		newNode("worker-1"),
+	}
+	for i := range expected {
+		expected[i].TypeMeta.APIVersion, expected[i].TypeMeta.Kind = "v1", "Node"
+	}
+	if !reflect.DeepEqual(expected, nodes) {
+		t.Errorf("expected nodes %+v, but got: %+v", expected, nodes)
+	}
+	if stats := ComputeStats(nodes); stats.Nodes != 2 || stats.NodesWithoutTaints != 1 {
+		t.Errorf("expected stats of 2 nodes with 1 untainted, but got: %+v", stats)
+	}
+}
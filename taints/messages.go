@@ -0,0 +1,122 @@
+package taints
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Reason is a stable code naming the class of a parse failure, for telling failures apart and
+// for looking up their message in a MessageCatalog.
+type Reason string
+
+const (
+	ReasonEmptySpec       Reason = "EmptySpec"
+	ReasonInvalidSpec     Reason = "InvalidSpec"
+	ReasonEmptyRemovalKey Reason = "EmptyRemovalKey"
+	ReasonInvalidKey      Reason = "InvalidKey"
+	ReasonInvalidValue    Reason = "InvalidValue"
+	ReasonInvalidEffect   Reason = "InvalidEffect"
+	ReasonMissingEffect   Reason = "MissingEffect"
+	ReasonUnknownBundle   Reason = "UnknownBundle"
+	ReasonDuplicateTaint  Reason = "DuplicateTaint"
+)
+
+// reasons maps the Err* errors to their reason codes.
+var reasons = map[error]Reason{
+	ErrEmptySpec:       ReasonEmptySpec,
+	ErrInvalidSpec:     ReasonInvalidSpec,
+	ErrEmptyRemovalKey: ReasonEmptyRemovalKey,
+	ErrInvalidKey:      ReasonInvalidKey,
+	ErrInvalidValue:    ReasonInvalidValue,
+	ErrInvalidEffect:   ReasonInvalidEffect,
+	ErrMissingEffect:   ReasonMissingEffect,
+	ErrUnknownBundle:   ReasonUnknownBundle,
+	ErrDuplicateTaint:  ReasonDuplicateTaint,
+}
+
+// MessageCatalog maps reason codes to text/template templates rendering the message of an error
+// with the reason. Templates of SpecError reasons are executed with the *SpecError, and the
+// template of ReasonDuplicateTaint with the *DuplicateTaintError. Reasons missing from a catalog
+// use the message of DefaultMessageCatalog.
+type MessageCatalog map[Reason]string
+
+// DefaultMessageCatalog holds the messages errors are rendered with by default.
+var DefaultMessageCatalog = MessageCatalog{
+	ReasonEmptySpec:       "invalid taint spec: empty spec",
+	ReasonInvalidSpec:     specMessage,
+	ReasonEmptyRemovalKey: "invalid taint spec: {{.Spec}}-, a removal requires a key",
+	ReasonInvalidKey:      specMessage,
+	ReasonInvalidValue:    specMessage,
+	ReasonInvalidEffect:   "invalid taint effect: {{.Value}}, unsupported taint effect{{with .Suggestion}}, did you mean {{.}}?{{end}}",
+	ReasonMissingEffect:   specMessage,
+	ReasonUnknownBundle:   specMessage,
+	ReasonDuplicateTaint:  "duplicated taints with the same key and effect: {{range $i, $d := .Duplicates}}{{if $i}}; {{end}}{{$d}}{{end}}",
+}
+
+const specMessage = "invalid taint spec: {{.Spec}}{{with .Detail}}, {{.}}{{end}}"
+
+// defaultMessages are the parsed templates of DefaultMessageCatalog.
+var defaultMessages = mustParseCatalog(DefaultMessageCatalog)
+
+// messages holds the parsed templates of a catalog.
+type messages map[Reason]*template.Template
+
+// parseCatalog parses the templates of the catalog.
+func parseCatalog(catalog MessageCatalog) (messages, error) {
+	parsed := messages{}
+	for reason, text := range catalog {
+		tmpl, err := template.New(string(reason)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message of reason %v: %w", reason, err)
+		}
+		parsed[reason] = tmpl
+	}
+	return parsed, nil
+}
+
+func mustParseCatalog(catalog MessageCatalog) messages {
+	parsed, err := parseCatalog(catalog)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// render renders the message of the reason for the error, falling back to the default message
+// if the catalog has none or its template fails.
+func (m messages) render(reason Reason, data any) string {
+	for _, catalog := range []messages{m, defaultMessages} {
+		tmpl, ok := catalog[reason]
+		if !ok {
+			continue
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err == nil {
+			return out.String()
+		}
+	}
+	return string(reason)
+}
+
+// WithMessageCatalog renders the messages of parse errors, and of warnings about duplicates, from
+// the templates of the catalog, such as for rewording or localizing them.
+func WithMessageCatalog(catalog MessageCatalog) Option {
+	return func(o *options) error {
+		parsed, err := parseCatalog(catalog)
+		if err != nil {
+			return err
+		}
+		o.messages = parsed
+		return nil
+	}
+}
+
+// localize renders the message of the spec error from the catalog of the options.
+func (o *options) localize(err error) {
+	var specErr *SpecError
+	if errors.As(err, &specErr) {
+		specErr.messages = o.messages
+	}
+}
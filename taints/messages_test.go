@@ -0,0 +1,109 @@
+package taints
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReason(t *testing.T) {
+	cases := []struct {
+		spec           []string
+		expectedReason Reason
+	}{
+		{spec: []string{""}, expectedReason: ReasonEmptySpec},
+		{spec: []string{"foo:NoSchedule:extra"}, expectedReason: ReasonInvalidSpec},
+		{spec: []string{"-"}, expectedReason: ReasonEmptyRemovalKey},
+		{spec: []string{"foo bar:NoSchedule"}, expectedReason: ReasonInvalidKey},
+		{spec: []string{"foo=a b:NoSchedule"}, expectedReason: ReasonInvalidValue},
+		{spec: []string{"foo:Never"}, expectedReason: ReasonInvalidEffect},
+		{spec: []string{"foo"}, expectedReason: ReasonMissingEffect},
+		{spec: []string{"foo:NoSchedule", "foo=bar:NoSchedule"}, expectedReason: ReasonDuplicateTaint},
+	}
+
+	for _, c := range cases {
+		_, _, err := ParseTaints(c.spec)
+		var reasoned interface{ Reason() Reason }
+		if !errors.As(err, &reasoned) {
+			t.Errorf("expected an error with a reason for spec %v, but got: %v", c.spec, err)
+			continue
+		}
+		if reasoned.Reason() != c.expectedReason {
+			t.Errorf("expected reason %v for spec %v, but got: %v", c.expectedReason, c.spec, reasoned.Reason())
+		}
+	}
+}
+
+func TestWithMessageCatalog(t *testing.T) {
+	catalog := MessageCatalog{
+		ReasonInvalidEffect:  "ungültiger Effekt {{.Value}} in {{.Spec}}",
+		ReasonDuplicateTaint: "{{len .Duplicates}} doppelte Taints",
+	}
+
+	cases := []struct {
+		name            string
+		spec            []string
+		catalog         MessageCatalog
+		expectedMessage string
+		expectedErr     bool
+	}{
+		{
+			name:            "overridden message",
+			spec:            []string{"foo:Never"},
+			catalog:         catalog,
+			expectedMessage: "ungültiger Effekt Never in foo:Never",
+		},
+		{
+			name:            "default message",
+			spec:            []string{"foo"},
+			catalog:         catalog,
+			expectedMessage: "invalid taint spec: foo",
+		},
+		{
+			name:            "overridden duplicate message",
+			spec:            []string{"foo:NoSchedule", "foo=bar:NoSchedule"},
+			catalog:         catalog,
+			expectedMessage: "1 doppelte Taints",
+		},
+		{
+			name:            "failing template",
+			spec:            []string{"foo:Never"},
+			catalog:         MessageCatalog{ReasonInvalidEffect: "{{.Missing}}"},
+			expectedMessage: "invalid taint effect: Never, unsupported taint effect",
+		},
+		{
+			name:        "malformed template",
+			spec:        []string{"foo:Never"},
+			catalog:     MessageCatalog{ReasonInvalidEffect: "{{.Value"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.spec, WithMessageCatalog(c.catalog))
+		if err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+			continue
+		}
+		if c.expectedErr {
+			var reasoned interface{ Reason() Reason }
+			if errors.As(err, &reasoned) {
+				t.Errorf("[%s] expected an option error, but got: %v", c.name, err)
+			}
+			continue
+		}
+		if err.Error() != c.expectedMessage {
+			t.Errorf("[%s] expected message %q, but got: %q", c.name, c.expectedMessage, err.Error())
+		}
+	}
+}
+
+func TestEmptyRemovalKeyMessage(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"-":   "invalid taint spec: -, a removal requires a key",
+		"=:-": "invalid taint spec: =:-, a removal requires a key",
+	} {
+		if _, _, err := ParseTaints([]string{spec}); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q for spec %q, but got: %v", expected, spec, err)
+		}
+	}
+}
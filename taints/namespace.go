@@ -0,0 +1,140 @@
+package taints
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	// DefaultTolerationsAnnotation is the namespace annotation of the PodTolerationRestriction
+	// admission plugin holding the tolerations added to the pods of the namespace.
+	DefaultTolerationsAnnotation = "scheduler.alpha.kubernetes.io/defaultTolerations"
+	// TolerationsWhitelistAnnotation is the namespace annotation of the PodTolerationRestriction
+	// admission plugin holding the tolerations the pods of the namespace may have.
+	TolerationsWhitelistAnnotation = "scheduler.alpha.kubernetes.io/tolerationsWhitelist"
+)
+
+// NamespaceTolerations is the toleration policy of a namespace, as held by its annotations.
+type NamespaceTolerations struct {
+	// Defaults are added to the pods of the namespace.
+	Defaults []v1.Toleration
+	// Whitelist restricts the tolerations of the pods of the namespace. A nil whitelist allows
+	// every toleration.
+	Whitelist []v1.Toleration
+}
+
+// ParseNamespaceTolerations reads the toleration policy of a namespace from its annotations,
+// whose values are JSON arrays of tolerations.
+func ParseNamespaceTolerations(annotations map[string]string) (NamespaceTolerations, error) {
+	var policy NamespaceTolerations
+	var err error
+	if policy.Defaults, err = parseTolerationsAnnotation(annotations, DefaultTolerationsAnnotation); err != nil {
+		return NamespaceTolerations{}, err
+	}
+	if policy.Whitelist, err = parseTolerationsAnnotation(annotations, TolerationsWhitelistAnnotation); err != nil {
+		return NamespaceTolerations{}, err
+	}
+	return policy, nil
+}
+
+func parseTolerationsAnnotation(annotations map[string]string, key string) ([]v1.Toleration, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	tolerations := []v1.Toleration{}
+	if err := json.Unmarshal([]byte(value), &tolerations); err != nil {
+		return nil, fmt.Errorf("invalid %v annotation: %v", key, err)
+	}
+	return tolerations, nil
+}
+
+// Annotations returns the namespace annotations holding the toleration policy. Annotations of
+// nil tolerations are left out.
+func (p NamespaceTolerations) Annotations() (map[string]string, error) {
+	annotations := map[string]string{}
+	for key, tolerations := range map[string][]v1.Toleration{
+		DefaultTolerationsAnnotation:   p.Defaults,
+		TolerationsWhitelistAnnotation: p.Whitelist,
+	} {
+		if tolerations == nil {
+			continue
+		}
+		value, err := json.Marshal(tolerations)
+		if err != nil {
+			return nil, err
+		}
+		annotations[key] = string(value)
+	}
+	return annotations, nil
+}
+
+// Apply returns the tolerations of a pod created in the namespace: the tolerations of the pod,
+// followed by the default tolerations whose key and effect it does not already tolerate. It
+// fails if the resulting tolerations are not allowed by the whitelist, like the
+// PodTolerationRestriction admission plugin rejects the pod.
+func (p NamespaceTolerations) Apply(tolerations []v1.Toleration) ([]v1.Toleration, error) {
+	merged := append([]v1.Toleration(nil), tolerations...)
+	for _, toleration := range p.Defaults {
+		conflicting := false
+		for i := range tolerations {
+			if tolerations[i].Key == toleration.Key && tolerations[i].Effect == toleration.Effect {
+				conflicting = true
+				break
+			}
+		}
+		if !conflicting {
+			merged = append(merged, toleration)
+		}
+	}
+	for _, toleration := range merged {
+		if !p.Allows(toleration) {
+			return nil, fmt.Errorf("toleration %+v is not allowed by the namespace tolerations whitelist", toleration)
+		}
+	}
+	return merged, nil
+}
+
+// Allows checks whether the whitelist allows a toleration, that is whether a whitelisted
+// toleration tolerates at least every taint the toleration does. An empty whitelist allows every
+// toleration.
+func (p NamespaceTolerations) Allows(toleration v1.Toleration) bool {
+	if len(p.Whitelist) == 0 {
+		return true
+	}
+	for _, allowed := range p.Whitelist {
+		if tolerationCovers(allowed, toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// tolerationCovers checks whether ss tolerates every taint t does.
+func tolerationCovers(ss, t v1.Toleration) bool {
+	if apiequality.Semantic.DeepEqual(ss, t) {
+		return true
+	}
+	if ss.Key != t.Key && !(len(ss.Key) == 0 && ss.Operator == v1.TolerationOpExists) {
+		return false
+	}
+	if len(ss.Effect) > 0 && ss.Effect != t.Effect {
+		return false
+	}
+	if ss.Effect == v1.TaintEffectNoExecute && ss.TolerationSeconds != nil {
+		if t.TolerationSeconds == nil || *t.TolerationSeconds > *ss.TolerationSeconds {
+			return false
+		}
+	}
+	switch ss.Operator {
+	case v1.TolerationOpEqual, "":
+		return (t.Operator == v1.TolerationOpEqual || len(t.Operator) == 0) && t.Value == ss.Value
+	case v1.TolerationOpExists:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParseNamespaceTolerations(t *testing.T) {
+	annotations := map[string]string{
+		DefaultTolerationsAnnotation:   `[{"key":"dedicated","operator":"Equal","value":"ml","effect":"NoSchedule"}]`,
+		TolerationsWhitelistAnnotation: `[]`,
+	}
+	policy, err := ParseNamespaceTolerations(annotations)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expected := NamespaceTolerations{
+		Defaults:  []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "ml", Effect: v1.TaintEffectNoSchedule}},
+		Whitelist: []v1.Toleration{},
+	}
+	if !reflect.DeepEqual(expected, policy) {
+		t.Errorf("expected policy %+v, but got: %+v", expected, policy)
+	}
+
+	formatted, err := policy.Annotations()
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	roundTripped, err := ParseNamespaceTolerations(formatted)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if !reflect.DeepEqual(policy, roundTripped) {
+		t.Errorf("expected policy %+v after a round trip, but got: %+v", policy, roundTripped)
+	}
+
+	if _, err := ParseNamespaceTolerations(map[string]string{DefaultTolerationsAnnotation: "dedicated=ml"}); err == nil {
+		t.Errorf("expected error for an invalid annotation, but got nothing")
+	}
+}
+
+func TestNamespaceTolerationsApply(t *testing.T) {
+	seconds := func(s int64) *int64 { return &s }
+	policy := NamespaceTolerations{
+		Defaults: []v1.Toleration{
+			{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "ml", Effect: v1.TaintEffectNoSchedule},
+			{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: seconds(60)},
+		},
+		Whitelist: []v1.Toleration{
+			{Key: "dedicated", Operator: v1.TolerationOpExists},
+			{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: seconds(300)},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		tolerations []v1.Toleration
+		expected    []v1.Toleration
+		expectedErr bool
+	}{
+		{
+			name:     "defaults added",
+			expected: policy.Defaults,
+		},
+		{
+			name:        "pod tolerations take precedence",
+			tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+			expected: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				policy.Defaults[1],
+			},
+		},
+		{
+			name:        "toleration not whitelisted",
+			tolerations: []v1.Toleration{{Key: "spot", Operator: v1.TolerationOpExists}},
+			expectedErr: true,
+		},
+		{
+			name:        "toleration seconds above whitelist",
+			tolerations: []v1.Toleration{{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: seconds(600)}},
+			expectedErr: true,
+		},
+	}
+	for _, c := range cases {
+		tolerations, err := policy.Apply(c.tolerations)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expected, tolerations) {
+			t.Errorf("[%s] expected tolerations %+v, but got: %+v", c.name, c.expected, tolerations)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package taints
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Notifier is told about taint changes after they have been made.
+type Notifier interface {
+	Notify(ctx context.Context, nodeName string, diff TaintDiff) error
+}
+
+// webhookNotification is the body posted by WebhookNotifier.
+type webhookNotification struct {
+	Node string    `json:"node"`
+	Diff TaintDiff `json:"diff"`
+}
+
+// WebhookNotifier is a Notifier posting changes as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+	slack  bool
+}
+
+// NewWebhookNotifier returns a WebhookNotifier posting a JSON object with the node name and the
+// TaintDiff to the URL. A nil client uses http.DefaultClient.
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookNotifier{url: url, client: client}
+}
+
+// NewSlackNotifier returns a WebhookNotifier posting changes to a Slack incoming webhook URL, as
+// a message with the unified diff of the change.
+func NewSlackNotifier(url string, client *http.Client) *WebhookNotifier {
+	notifier := NewWebhookNotifier(url, client)
+	notifier.slack = true
+	return notifier
+}
+
+// Notify posts the change. Empty diffs are not posted.
+func (n *WebhookNotifier) Notify(ctx context.Context, nodeName string, diff TaintDiff) error {
+	if diff.IsEmpty() {
+		return nil
+	}
+
+	var body interface{} = webhookNotification{Node: nodeName, Diff: diff}
+	if n.slack {
+		body = map[string]string{
+			"text": fmt.Sprintf("Taints of node %s changed:\n```\n%s```", nodeName, diff.RenderUnified()),
+		}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notifying %v: unexpected status %v", n.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package taints
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var received []string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	diff := DiffTaints(nil, []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}})
+	cases := []struct {
+		name             string
+		notifier         Notifier
+		diff             TaintDiff
+		status           int
+		expectedReceived []string
+		expectedErr      bool
+	}{
+		{
+			name:     "empty diff",
+			notifier: NewWebhookNotifier(server.URL, nil),
+		},
+		{
+			name:             "webhook",
+			notifier:         NewWebhookNotifier(server.URL, nil),
+			diff:             diff,
+			expectedReceived: []string{`{"node":"worker-1","diff":{"added":[{"key":"foo","effect":"NoSchedule"}]}}`},
+		},
+		{
+			name:             "slack",
+			notifier:         NewSlackNotifier(server.URL, server.Client()),
+			diff:             diff,
+			expectedReceived: []string{`{"text":"Taints of node worker-1 changed:\n` + "```" + `\n--- current\n+++ desired\n@@ -0,0 +1,1 @@\n+foo:NoSchedule\n` + "```" + `"}`},
+		},
+		{
+			name:             "failing webhook",
+			notifier:         NewWebhookNotifier(server.URL, nil),
+			diff:             diff,
+			status:           http.StatusBadGateway,
+			expectedReceived: []string{`{"node":"worker-1","diff":{"added":[{"key":"foo","effect":"NoSchedule"}]}}`},
+			expectedErr:      true,
+		},
+	}
+
+	for _, c := range cases {
+		received, status = nil, http.StatusOK
+		if c.status != 0 {
+			status = c.status
+		}
+		err := c.notifier.Notify(context.Background(), "worker-1", c.diff)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if len(received) != len(c.expectedReceived) || (len(received) > 0 && received[0] != c.expectedReceived[0]) {
+			t.Errorf("[%s] expected notifications %q, but got: %q", c.name, c.expectedReceived, received)
+		}
+	}
+}
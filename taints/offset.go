@@ -0,0 +1,121 @@
+package taints
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Snippet returns the spec with a caret inserted before the byte at which the failure was found,
+// such as 'foo=ab^ c:NoSchedule', for pointing at the failure in long specs.
+func (e *SpecError) Snippet() string {
+	offset := min(max(e.Offset, 0), len(e.Spec))
+	return e.Spec[:offset] + "^" + e.Spec[offset:]
+}
+
+// secondIndex returns the index of the second occurrence of sep in s, or -1 if there is none.
+func secondIndex(s string, sep byte) int {
+	first := strings.IndexByte(s, sep)
+	if first < 0 {
+		return -1
+	}
+	second := strings.IndexByte(s[first+1:], sep)
+	if second < 0 {
+		return -1
+	}
+	return first + 1 + second
+}
+
+// keyErrorOffset returns the offset of the first byte of an invalid key that makes it invalid,
+// checking the prefix and the name of the key separately.
+func keyErrorOffset(key string) int {
+	if i := secondIndex(key, '/'); i >= 0 {
+		return i
+	}
+	prefix, name, hasPrefix := strings.Cut(key, "/")
+	if !hasPrefix {
+		return nameErrorOffset(key, validation.LabelValueMaxLength)
+	}
+	if offset := dns1123SubdomainErrorOffset(prefix); offset < len(prefix) {
+		return offset
+	}
+	return len(prefix) + 1 + nameErrorOffset(name, validation.LabelValueMaxLength)
+}
+
+// nameErrorOffset returns the offset of the first byte of a name, made of alphanumerics, '-', '_'
+// and '.' and starting and ending with an alphanumeric, that makes it invalid, or len(s) if there
+// is none.
+func nameErrorOffset(s string, maxLength int) int {
+	for i := 0; i < len(s); i++ {
+		if !isAlphanumeric(s[i]) && s[i] != '-' && s[i] != '_' && s[i] != '.' {
+			return i
+		}
+	}
+	switch {
+	case len(s) == 0:
+		return 0
+	case !isAlphanumeric(s[0]):
+		return 0
+	case !isAlphanumeric(s[len(s)-1]):
+		return len(s) - 1
+	case len(s) > maxLength:
+		return maxLength
+	}
+	return len(s)
+}
+
+// dns1123SubdomainErrorOffset returns the offset of the first byte of a DNS-1123 subdomain, made of
+// lowercase alphanumerics and '-' in labels separated by '.', each starting and ending with an
+// alphanumeric, that makes it invalid, or len(s) if there is none.
+func dns1123SubdomainErrorOffset(s string) int {
+	if len(s) == 0 {
+		return 0
+	}
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != '.' {
+			if c := s[i]; !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-') {
+				return i
+			}
+			continue
+		}
+		// s[start:i] is a label
+		switch {
+		case i == start:
+			return min(i, len(s)-1)
+		case s[start] == '-':
+			return start
+		case s[i-1] == '-':
+			return i - 1
+		}
+		start = i + 1
+	}
+	if len(s) > validation.DNS1123SubdomainMaxLength {
+		return validation.DNS1123SubdomainMaxLength
+	}
+	return len(s)
+}
+
+// isQualifiedName reports whether the key is a qualified name like validation.IsQualifiedName,
+// without allocating, which keeps parsing valid specs free of allocations. Only the reasons of
+// invalid keys are left to validation.IsQualifiedName.
+func isQualifiedName(key string) bool {
+	name := key
+	if prefix, suffix, hasPrefix := strings.Cut(key, "/"); hasPrefix {
+		if !isDNS1123Subdomain(prefix) {
+			return false
+		}
+		name = suffix
+	}
+	return len(name) > 0 && nameErrorOffset(name, validation.LabelValueMaxLength) == len(name)
+}
+
+// isDNS1123Subdomain reports whether s is a DNS-1123 subdomain like
+// validation.IsDNS1123Subdomain.
+func isDNS1123Subdomain(s string) bool {
+	return len(s) > 0 && dns1123SubdomainErrorOffset(s) == len(s)
+}
+
+func isAlphanumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
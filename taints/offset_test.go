@@ -0,0 +1,137 @@
+package taints
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSpecErrorSnippet(t *testing.T) {
+	cases := []struct {
+		name            string
+		spec            string
+		opts            []Option
+		expectedSnippet string
+	}{
+		{
+			name:            "invalid value character",
+			spec:            "foo=ab c:NoSchedule",
+			expectedSnippet: "foo=ab^ c:NoSchedule",
+		},
+		{
+			name:            "value ending with a separator",
+			spec:            "foo=abc-:NoSchedule",
+			expectedSnippet: "foo=abc^-:NoSchedule",
+		},
+		{
+			name:            "value too long",
+			spec:            "foo=" + strings.Repeat("a", 70) + ":NoSchedule",
+			expectedSnippet: "foo=" + strings.Repeat("a", 63) + "^aaaaaaa:NoSchedule",
+		},
+		{
+			name:            "invalid key character",
+			spec:            "fo*o:NoSchedule",
+			expectedSnippet: "fo^*o:NoSchedule",
+		},
+		{
+			name:            "invalid key name",
+			spec:            "example.com/-foo:NoSchedule",
+			expectedSnippet: "example.com/^-foo:NoSchedule",
+		},
+		{
+			name:            "uppercase key prefix",
+			spec:            "Example.com/foo:NoSchedule",
+			expectedSnippet: "^Example.com/foo:NoSchedule",
+		},
+		{
+			name:            "key prefix with an underscore",
+			spec:            "foo_bar/baz:NoSchedule",
+			expectedSnippet: "foo^_bar/baz:NoSchedule",
+		},
+		{
+			name:            "key prefix ending with a dot",
+			spec:            "example.com./foo:NoSchedule",
+			expectedSnippet: "example.com^./foo:NoSchedule",
+		},
+		{
+			name:            "key with two prefixes",
+			spec:            "example.com/foo/bar:NoSchedule",
+			expectedSnippet: "example.com/foo^/bar:NoSchedule",
+		},
+		{
+			name:            "invalid effect",
+			spec:            "foo=abc:Never",
+			expectedSnippet: "foo=abc:^Never",
+		},
+		{
+			name:            "too many separators",
+			spec:            "foo=abc=xyz:NoSchedule",
+			expectedSnippet: "foo=abc^=xyz:NoSchedule",
+		},
+		{
+			name:            "too many effects",
+			spec:            "foo:NoSchedule:NoExecute",
+			expectedSnippet: "foo:NoSchedule^:NoExecute",
+		},
+		{
+			name:            "missing effect",
+			spec:            "foo",
+			expectedSnippet: "foo^",
+		},
+		{
+			name:            "value without effect",
+			spec:            "foo=abc",
+			expectedSnippet: "foo^=abc",
+		},
+		{
+			name:            "removal",
+			spec:            "fo*o:NoSchedule-",
+			expectedSnippet: "fo^*o:NoSchedule",
+		},
+		{
+			name:            "strict empty value",
+			spec:            "foo=:NoSchedule",
+			opts:            []Option{WithStrict()},
+			expectedSnippet: "foo^=:NoSchedule",
+		},
+		{
+			name:            "strict removal value",
+			spec:            "foo=abc:NoSchedule-",
+			opts:            []Option{WithStrict()},
+			expectedSnippet: "foo^=abc:NoSchedule",
+		},
+	}
+
+	for _, c := range cases {
+		_, _, err := ParseTaintsWithOptions([]string{c.spec}, c.opts...)
+		var specErr *SpecError
+		if !errors.As(err, &specErr) {
+			t.Errorf("[%s] expected a SpecError, but got: %v", c.name, err)
+			continue
+		}
+		// the spec of removals is reported without the '-' suffix
+		if spec := strings.TrimSuffix(c.spec, "-"); specErr.Spec != spec {
+			t.Errorf("[%s] expected spec %q, but got: %q", c.name, spec, specErr.Spec)
+		}
+		if snippet := specErr.Snippet(); snippet != c.expectedSnippet {
+			t.Errorf("[%s] expected snippet %q, but got: %q", c.name, c.expectedSnippet, snippet)
+		}
+	}
+}
+
+func TestIsQualifiedName(t *testing.T) {
+	keys := []string{
+		"foo", "foo.bar_baz-1", "example.com/foo", "node-role.kubernetes.io/control-plane", "a/b",
+		"", "/", "foo/", "/foo", "-foo", "foo-", "foo bar", "Example.com/foo", "example..com/foo",
+		"-example.com/foo", "example-.com/foo", "example.com/foo/bar", "example.com/_foo",
+		strings.Repeat("a", 63), strings.Repeat("a", 64), strings.Repeat("a", 253) + "/foo", strings.Repeat("a", 254) + "/foo",
+	}
+	for _, key := range keys {
+		expected := len(validation.IsQualifiedName(key)) == 0
+		if valid := isQualifiedName(key); valid != expected {
+			t.Errorf("expected isQualifiedName(%q) to be %v, but got: %v", key, expected, valid)
+		}
+	}
+}
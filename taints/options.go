@@ -0,0 +1,148 @@
+package taints
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Option adjusts how ParseTaintsWithOptions parses and validates a spec, or how a Formatter
+// writes one. Options that do not apply to one of them are ignored by it.
+type Option func(*options) error
+
+// options holds the settings accumulated from a list of Option values.
+type options struct {
+	// profile holds the validation rules of the targeted Kubernetes version.
+	profile validationProfile
+	// allowUnknownEffects accepts effects outside of the profile as opaque strings.
+	allowUnknownEffects bool
+	// effectTranslation maps external effect names to taint effects.
+	effectTranslation EffectTranslation
+	// caseInsensitiveEffects accepts effect names in any case and with word separators.
+	caseInsensitiveEffects bool
+	// emptyValueSeparator writes '=' for taints with an empty value.
+	emptyValueSeparator bool
+	// sorted orders taints by key, effect and value.
+	sorted bool
+	// bundles are expanded from '@<name>' references in specs.
+	bundles Bundles
+	// knownKeys are the keys removals are expected to use, besides well-known keys.
+	knownKeys sets.Set[string]
+	// strict rejects specs that are valid but not in canonical form.
+	strict bool
+	// trimSpace removes leading and trailing white space from specs.
+	trimSpace bool
+	// skipEmpty ignores empty and whitespace-only specs.
+	skipEmpty bool
+	// duplicates is how taints added more than once are handled.
+	duplicates DuplicateHandling
+	// allowedEffects restricts the effects of the profile, if set.
+	allowedEffects sets.Set[v1.TaintEffect]
+	// policies restrict which taints may be added.
+	policies []Policy
+	// hooks are notified of parse results.
+	hooks Hooks
+	// messages render the messages of parse errors.
+	messages messages
+	// warn receives non-fatal findings about the spec.
+	warn func(string)
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := &options{
+		profile: validationProfiles[len(validationProfiles)-1],
+		hooks:   nopHooks{},
+		warn:    func(string) {},
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+func (o *options) warnf(format string, args ...interface{}) {
+	o.warn(fmt.Sprintf(format, args...))
+}
+
+// WithWarningHandler registers a function receiving warnings about specs that are accepted but
+// may not behave as expected. Warnings are discarded by default.
+func WithWarningHandler(handler func(warning string)) Option {
+	return func(o *options) error {
+		o.warn = handler
+		return nil
+	}
+}
+
+// WithUnknownEffects accepts effects that are not known to the targeted Kubernetes version and
+// passes them through unchanged, reporting a warning for each instead of failing. This allows
+// taints created by a newer control plane to flow through older builds.
+func WithUnknownEffects() Option {
+	return func(o *options) error {
+		o.allowUnknownEffects = true
+		return nil
+	}
+}
+
+// WithStrict rejects specs that are valid but not in canonical form, as returned by Normalize:
+// adds with an empty value written with the '=' separator, such as 'foo=:NoSchedule', and
+// removals with a value, such as 'foo=abc:NoSchedule-', whose value is ignored.
+func WithStrict() Option {
+	return func(o *options) error {
+		o.strict = true
+		return nil
+	}
+}
+
+// WithSkipEmpty ignores empty and whitespace-only specs, as produced by splitting lists with
+// trailing separators, instead of failing with ErrEmptySpec.
+func WithSkipEmpty() Option {
+	return func(o *options) error {
+		o.skipEmpty = true
+		return nil
+	}
+}
+
+// WithTrimSpace removes leading and trailing white space from each spec before it is parsed, such
+// as left behind by YAML folding or shell word splitting, instead of failing key validation.
+func WithTrimSpace() Option {
+	return func(o *options) error {
+		o.trimSpace = true
+		return nil
+	}
+}
+
+// DuplicateHandling is how specs adding taints with the same key and effect are handled.
+type DuplicateHandling int
+
+const (
+	// DuplicatesError fails the parse, which is the default.
+	DuplicatesError DuplicateHandling = iota
+	// DuplicatesKeepFirst keeps the taint of the first spec, reporting a warning.
+	DuplicatesKeepFirst
+	// DuplicatesKeepLast keeps the value of the last spec, at the position of the first one,
+	// reporting a warning.
+	DuplicatesKeepLast
+)
+
+// WithDuplicates sets how specs adding taints with the same key and effect are handled.
+func WithDuplicates(handling DuplicateHandling) Option {
+	return func(o *options) error {
+		if handling < DuplicatesError || handling > DuplicatesKeepLast {
+			return fmt.Errorf("invalid duplicate handling: %d", handling)
+		}
+		o.duplicates = handling
+		return nil
+	}
+}
+
+// WithAllowedEffects only accepts the given effects, such as NoSchedule alone for tools that
+// must never evict pods. Effects unknown to the targeted Kubernetes version are still rejected.
+func WithAllowedEffects(effects ...v1.TaintEffect) Option {
+	return func(o *options) error {
+		o.allowedEffects = sets.New(effects...)
+		return nil
+	}
+}
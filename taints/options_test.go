@@ -0,0 +1,201 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWithUnknownEffects(t *testing.T) {
+	cases := []struct {
+		name                   string
+		spec                   []string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedWarnings       []string
+		expectedErr            bool
+	}{
+		{
+			name: "known effects produce no warnings",
+			spec: []string{"foo=abc:NoSchedule"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name: "unknown effects are passed through",
+			spec: []string{"foo=abc:NoScheduleLater", "bar:NoScheduleLater-"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: "NoScheduleLater"},
+			},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "bar", Effect: "NoScheduleLater"},
+			},
+			expectedWarnings: []string{
+				"unknown taint effect: NoScheduleLater, passing it through unchanged",
+				"unknown taint effect: NoScheduleLater, passing it through unchanged",
+			},
+		},
+		{
+			name:        "empty effects are still rejected",
+			spec:        []string{"foo=abc:"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		var warnings []string
+		taints, taintsToRemove, err := ParseTaintsWithOptions(c.spec, WithUnknownEffects(), WithWarningHandler(func(w string) {
+			warnings = append(warnings, w)
+		}))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+		if !reflect.DeepEqual(c.expectedWarnings, warnings) {
+			t.Errorf("[%s] expected warnings %v, but got: %v", c.name, c.expectedWarnings, warnings)
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	cases := []struct {
+		name                   string
+		spec                   []string
+		opts                   []Option
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedWarnings       []string
+		expectedErr            bool
+	}{
+		{
+			name:           "strict canonical specs",
+			spec:           []string{"foo:NoSchedule", "bar:NoExecute-"},
+			opts:           []Option{WithStrict()},
+			expectedTaints: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:        "strict empty value separator",
+			spec:        []string{"foo=:NoSchedule"},
+			opts:        []Option{WithStrict()},
+			expectedErr: true,
+		},
+		{
+			name:        "strict removal value",
+			spec:        []string{"foo=abc:NoSchedule-"},
+			opts:        []Option{WithStrict()},
+			expectedErr: true,
+		},
+		{
+			name:           "duplicates keeping the first taint",
+			spec:           []string{"foo=abc:NoSchedule", "bar:NoExecute", "foo=xyz:NoSchedule"},
+			opts:           []Option{WithDuplicates(DuplicatesKeepFirst)},
+			expectedTaints: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}, {Key: "bar", Effect: v1.TaintEffectNoExecute}},
+			expectedWarnings: []string{
+				"duplicated taints with the same key and effect: foo:NoSchedule (specs 0, 2)",
+			},
+		},
+		{
+			name:           "duplicates keeping the last value",
+			spec:           []string{"foo=abc:NoSchedule", "bar:NoExecute", "foo=xyz:NoSchedule"},
+			opts:           []Option{WithDuplicates(DuplicatesKeepLast)},
+			expectedTaints: []v1.Taint{{Key: "foo", Value: "xyz", Effect: v1.TaintEffectNoSchedule}, {Key: "bar", Effect: v1.TaintEffectNoExecute}},
+			expectedWarnings: []string{
+				"duplicated taints with the same key and effect: foo:NoSchedule (specs 0, 2)",
+			},
+		},
+		{
+			name:        "empty specs",
+			spec:        []string{"foo:NoSchedule", "", " "},
+			expectedErr: true,
+		},
+		{
+			name:           "empty specs skipped",
+			spec:           []string{"", "foo:NoSchedule", " \t", "bar-"},
+			opts:           []Option{WithSkipEmpty()},
+			expectedTaints: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "bar"},
+			},
+		},
+		{
+			name:        "untrimmed specs",
+			spec:        []string{" foo=bar:NoSchedule "},
+			expectedErr: true,
+		},
+		{
+			name:           "trimmed specs",
+			spec:           []string{" foo=bar:NoSchedule ", "\tbar-\n"},
+			opts:           []Option{WithTrimSpace()},
+			expectedTaints: []v1.Taint{{Key: "foo", Value: "bar", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "bar"},
+			},
+		},
+		{
+			name:        "invalid duplicate handling",
+			spec:        []string{"foo:NoSchedule"},
+			opts:        []Option{WithDuplicates(DuplicateHandling(7))},
+			expectedErr: true,
+		},
+		{
+			name:        "allowed effects",
+			spec:        []string{"foo:NoSchedule", "bar:NoExecute-"},
+			opts:        []Option{WithAllowedEffects(v1.TaintEffectNoSchedule)},
+			expectedErr: true,
+		},
+		{
+			name:           "allowed effects with a matching spec",
+			spec:           []string{"foo:NoSchedule"},
+			opts:           []Option{WithAllowedEffects(v1.TaintEffectNoSchedule)},
+			expectedTaints: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			name:        "allowed effects with unknown effects",
+			spec:        []string{"foo:NoSchedule", "bar:Later"},
+			opts:        []Option{WithAllowedEffects(v1.TaintEffectNoSchedule), WithUnknownEffects()},
+			expectedErr: true,
+		},
+		{
+			name:        "allowed unknown effect",
+			spec:        []string{"bar:Later"},
+			opts:        []Option{WithAllowedEffects("Later"), WithUnknownEffects()},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		var warnings []string
+		opts := append(c.opts, WithWarningHandler(func(w string) {
+			warnings = append(warnings, w)
+		}))
+		taints, taintsToRemove, err := ParseTaintsWithOptions(c.spec, opts...)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+		if !reflect.DeepEqual(c.expectedWarnings, warnings) {
+			t.Errorf("[%s] expected warnings %v, but got: %v", c.name, c.expectedWarnings, warnings)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package taints
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// taintsPath is the JSON pointer of the taints of a node.
+const taintsPath = "/spec/taints"
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes the operation, keeping the value of test operations even if it is null.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "test" {
+		return json.Marshal(struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}{op.Op, op.Path, op.Value})
+	}
+	type operation PatchOperation
+	return json.Marshal(operation(op))
+}
+
+// PatchOperations returns the JSON Patch operations applying the diff to a node whose taints are
+// the current taints of the diff. The patch starts with a test of the current taints, so it
+// fails instead of applying to taints that changed in the meantime. For a node without taints,
+// the test expects null, which the JSON Patch implementation of the API server accepts for the
+// absent taints field. An empty diff has no operations.
+func (d TaintDiff) PatchOperations() []PatchOperation {
+	if d.IsEmpty() {
+		return nil
+	}
+	if len(d.Current) == 0 {
+		return []PatchOperation{
+			{Op: "test", Path: taintsPath, Value: nil},
+			{Op: "add", Path: taintsPath, Value: d.Added},
+		}
+	}
+
+	ops := []PatchOperation{{Op: "test", Path: taintsPath, Value: d.Current}}
+	// Walk backwards so that removals do not shift the indices of the remaining operations.
+	for i := len(d.Current) - 1; i >= 0; i-- {
+		path := fmt.Sprintf("%s/%d", taintsPath, i)
+		if indexOfTaint(d.Removed, &d.Current[i]) >= 0 {
+			ops = append(ops, PatchOperation{Op: "remove", Path: path})
+			continue
+		}
+		for _, change := range d.Changed {
+			if change.From.MatchTaint(&d.Current[i]) {
+				ops = append(ops, PatchOperation{Op: "replace", Path: path, Value: change.To})
+				break
+			}
+		}
+	}
+	for _, taint := range d.Added {
+		ops = append(ops, PatchOperation{Op: "add", Path: taintsPath + "/-", Value: taint})
+	}
+	return ops
+}
+
+// JSONPatch returns the PatchOperations of the diff encoded as a JSON Patch document.
+func (d TaintDiff) JSONPatch() ([]byte, error) {
+	ops := d.PatchOperations()
+	if ops == nil {
+		ops = []PatchOperation{}
+	}
+	return json.Marshal(ops)
+}
@@ -0,0 +1,57 @@
+package taints
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestJSONPatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  []v1.Taint
+		desired  []v1.Taint
+		expected string
+	}{
+		{
+			name:     "no changes",
+			current:  []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			desired:  []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expected: `[]`,
+		},
+		{
+			name:     "node without taints",
+			desired:  []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expected: `[{"op":"test","path":"/spec/taints","value":null},{"op":"add","path":"/spec/taints","value":[{"key":"foo","effect":"NoSchedule"}]}]`,
+		},
+		{
+			name: "added, removed and changed taints",
+			current: []v1.Taint{
+				{Key: "foo", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoSchedule},
+				{Key: "baz", Effect: v1.TaintEffectNoExecute},
+			},
+			desired: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "qux", Effect: v1.TaintEffectNoExecute},
+			},
+			expected: `[` +
+				`{"op":"test","path":"/spec/taints","value":[{"key":"foo","effect":"NoSchedule"},{"key":"bar","effect":"NoSchedule"},{"key":"baz","effect":"NoExecute"}]},` +
+				`{"op":"remove","path":"/spec/taints/2"},` +
+				`{"op":"remove","path":"/spec/taints/1"},` +
+				`{"op":"replace","path":"/spec/taints/0","value":{"key":"foo","value":"abc","effect":"NoSchedule"}},` +
+				`{"op":"add","path":"/spec/taints/-","value":{"key":"qux","effect":"NoExecute"}}` +
+				`]`,
+		},
+	}
+
+	for _, c := range cases {
+		patch, err := DiffTaints(c.current, c.desired).JSONPatch()
+		if err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if string(patch) != c.expected {
+			t.Errorf("[%s] expected patch %s, but got: %s", c.name, c.expected, patch)
+		}
+	}
+}
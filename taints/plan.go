@@ -0,0 +1,74 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// AddAction classifies what adding a taint does to a node's existing taints.
+type AddAction string
+
+const (
+	// AddNew adds a taint whose key and effect are not present on the node.
+	AddNew AddAction = "New"
+	// AddIdentical adds a taint that is already present with the same value, which is a no-op.
+	AddIdentical AddAction = "Identical"
+	// AddOverwrite replaces the value of a taint with the same key and effect.
+	AddOverwrite AddAction = "Overwrite"
+)
+
+// PlannedAdd describes how a single taint add applies to a node.
+type PlannedAdd struct {
+	Taint  v1.Taint  `json:"taint"`
+	Action AddAction `json:"action"`
+	// OldValue is the value being replaced when Action is AddOverwrite.
+	OldValue string `json:"oldValue,omitempty"`
+}
+
+// AddPlan is the classification of a list of taint adds against a node's taints.
+type AddPlan []PlannedAdd
+
+// PlanAdds classifies each of the adds, as returned by ParseTaints, against the current taints
+// of a node. Taints are matched by key and effect.
+func PlanAdds(current []v1.Taint, adds []v1.Taint) AddPlan {
+	plan := make(AddPlan, 0, len(adds))
+	for _, add := range adds {
+		planned := PlannedAdd{Taint: add, Action: AddNew}
+		for i := range current {
+			if !current[i].MatchTaint(&add) {
+				continue
+			}
+			if current[i].Value == add.Value {
+				planned.Action = AddIdentical
+			} else {
+				planned.Action = AddOverwrite
+				planned.OldValue = current[i].Value
+			}
+			break
+		}
+		plan = append(plan, planned)
+	}
+	return plan
+}
+
+// PlanAddsWithPolicy is like PlanAdds, but returns the error of the first add forbidden by any of
+// the policies, so that plans enforce the same policies as parsing with WithPolicy.
+func PlanAddsWithPolicy(current []v1.Taint, adds []v1.Taint, policies ...Policy) (AddPlan, error) {
+	for _, add := range adds {
+		if err := checkPolicies(add, policies); err != nil {
+			return nil, err
+		}
+	}
+	return PlanAdds(current, adds), nil
+}
+
+// Overwrites returns the adds that replace the value of an existing taint, which kubectl only
+// performs when --overwrite is given.
+func (p AddPlan) Overwrites() []PlannedAdd {
+	var overwrites []PlannedAdd
+	for _, planned := range p {
+		if planned.Action == AddOverwrite {
+			overwrites = append(overwrites, planned)
+		}
+	}
+	return overwrites
+}
@@ -0,0 +1,53 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPlanAdds(t *testing.T) {
+	current := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "bar", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+	}
+
+	cases := []struct {
+		name               string
+		adds               []v1.Taint
+		expectedPlan       AddPlan
+		expectedOverwrites []PlannedAdd
+	}{
+		{
+			name:         "no adds",
+			expectedPlan: AddPlan{},
+		},
+		{
+			name: "new, identical and overwriting adds",
+			adds: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoExecute},
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+			},
+			expectedPlan: AddPlan{
+				{Taint: v1.Taint{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoExecute}, Action: AddNew},
+				{Taint: v1.Taint{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}, Action: AddIdentical},
+				{Taint: v1.Taint{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule}, Action: AddOverwrite, OldValue: "abc"},
+			},
+			expectedOverwrites: []PlannedAdd{
+				{Taint: v1.Taint{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule}, Action: AddOverwrite, OldValue: "abc"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		plan := PlanAdds(current, c.adds)
+		if !reflect.DeepEqual(c.expectedPlan, plan) {
+			t.Errorf("[%s] expected plan %v, but got: %v", c.name, c.expectedPlan, plan)
+		}
+		if overwrites := plan.Overwrites(); !reflect.DeepEqual(c.expectedOverwrites, overwrites) {
+			t.Errorf("[%s] expected overwrites %v, but got: %v", c.name, c.expectedOverwrites, overwrites)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package taints
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Policy restricts which taints may be added, on top of the syntax checks of the parser.
+type Policy interface {
+	// CheckAdd returns an error if the taint may not be added.
+	CheckAdd(taint v1.Taint) error
+}
+
+// WithPolicy rejects specs adding taints that violate the policy.
+func WithPolicy(policy Policy) Option {
+	return func(o *options) error {
+		o.policies = append(o.policies, policy)
+		return nil
+	}
+}
+
+// noExecuteAllowList is a Policy forbidding NoExecute taints with keys outside of an allow-list.
+type noExecuteAllowList struct {
+	keys sets.Set[string]
+}
+
+// NoExecuteAllowList returns a Policy that forbids adding NoExecute taints unless their key is
+// one of the given keys. NoExecute taints evict running pods, which makes mistakes with them the
+// most damaging.
+func NoExecuteAllowList(keys ...string) Policy {
+	return &noExecuteAllowList{keys: sets.New(keys...)}
+}
+
+// checkPolicies returns the error of the first of the policies forbidding the taint to be added.
+func checkPolicies(taint v1.Taint, policies []Policy) error {
+	for _, policy := range policies {
+		if err := policy.CheckAdd(taint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *noExecuteAllowList) CheckAdd(taint v1.Taint) error {
+	if taint.Effect == v1.TaintEffectNoExecute && !p.keys.Has(taint.Key) {
+		return fmt.Errorf("taint %q is not allowed: key %v is not allowed to use the NoExecute effect", taint.ToString(), taint.Key)
+	}
+	return nil
+}
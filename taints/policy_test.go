@@ -0,0 +1,86 @@
+package taints
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNoExecuteAllowList(t *testing.T) {
+	cases := []struct {
+		name        string
+		spec        []string
+		expectedErr bool
+	}{
+		{
+			name: "allow-listed NoExecute key",
+			spec: []string{"maintenance=true:NoExecute"},
+		},
+		{
+			name: "other effects are not restricted",
+			spec: []string{"foo=abc:NoSchedule", "bar:PreferNoSchedule"},
+		},
+		{
+			name: "removals are not restricted",
+			spec: []string{"foo:NoExecute-"},
+		},
+		{
+			name:        "NoExecute key outside of the allow-list",
+			spec:        []string{"maintenance=true:NoExecute", "foo=abc:NoExecute"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		_, _, err := ParseTaintsWithOptions(c.spec, WithPolicy(NoExecuteAllowList("maintenance")))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+	}
+}
+
+func TestNoExecuteAllowListPlanAndApply(t *testing.T) {
+	policy := NoExecuteAllowList("maintenance")
+	node := &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{{Key: "bar", Effect: v1.TaintEffectNoSchedule}}}}
+	allowed := v1.Taint{Key: "maintenance", Value: "true", Effect: v1.TaintEffectNoExecute}
+	forbidden := v1.Taint{Key: "foo", Effect: v1.TaintEffectNoExecute}
+
+	cases := []struct {
+		name  string
+		apply func(taint v1.Taint) error
+	}{
+		{
+			name: "plan",
+			apply: func(taint v1.Taint) error {
+				_, err := PlanAddsWithPolicy(node.Spec.Taints, []v1.Taint{taint}, policy)
+				return err
+			},
+		},
+		{
+			name: "add or update",
+			apply: func(taint v1.Taint) error {
+				_, _, err := AddOrUpdateTaint(node, &taint, WithPolicy(policy))
+				return err
+			},
+		},
+		{
+			name: "reorganize",
+			apply: func(taint v1.Taint) error {
+				_, _, err := ReorganizeTaints(node, false, []v1.Taint{taint}, nil, WithPolicy(policy))
+				return err
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if err := c.apply(allowed); err != nil {
+			t.Errorf("[%s] expected no error for %v, but got: %v", c.name, allowed.ToString(), err)
+		}
+		if err := c.apply(forbidden); err == nil {
+			t.Errorf("[%s] expected error for %v, but got nothing", c.name, forbidden.ToString())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package taints
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+)
+
+// protoSerializer encodes and decodes core/v1 objects in the Kubernetes protobuf format, as
+// served by the API server for 'application/vnd.kubernetes.protobuf'.
+var protoSerializer = func() *protobuf.Serializer {
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return protobuf.NewSerializer(scheme, scheme)
+}()
+
+// EncodeTaintsProto encodes taints in protobuf, as a NodeSpec message holding only the taints,
+// so that the encoding is that of the taints field of an encoded node spec.
+func EncodeTaintsProto(taints []v1.Taint) ([]byte, error) {
+	spec := v1.NodeSpec{Taints: taints}
+	return spec.Marshal()
+}
+
+// DecodeTaintsProto decodes taints encoded by EncodeTaintsProto, or the taints of an encoded
+// NodeSpec message.
+func DecodeTaintsProto(data []byte) ([]v1.Taint, error) {
+	var spec v1.NodeSpec
+	if err := spec.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("invalid taints: %v", err)
+	}
+	return spec.Taints, nil
+}
+
+// EncodeNodeProto encodes a node in the Kubernetes protobuf format.
+func EncodeNodeProto(node *v1.Node) ([]byte, error) {
+	node = node.DeepCopy()
+	node.APIVersion, node.Kind = "v1", "Node"
+	var out bytes.Buffer
+	if err := protoSerializer.Encode(node, &out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// DecodeNodesProto decodes a Node or a NodeList in the Kubernetes protobuf format, such as a
+// node snapshot stored by EncodeNodeProto or read from the API server, returning the nodes in
+// order.
+func DecodeNodesProto(data []byte) ([]v1.Node, error) {
+	obj, _, err := protoSerializer.Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nodes: %v", err)
+	}
+	switch obj := obj.(type) {
+	case *v1.Node:
+		return []v1.Node{*obj}, nil
+	case *v1.NodeList:
+		return obj.Items, nil
+	default:
+		return nil, fmt.Errorf("invalid nodes: unexpected %v", obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+}
+
+// decodeProto decodes taints to be added encoded by EncodeTaintsProto. The taints are validated
+// like their equivalent specs.
+func decodeProto(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	taints, err := DecodeTaintsProto(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateDecodedTaints(taints); err != nil {
+		return nil, nil, err
+	}
+	return taints, nil, nil
+}
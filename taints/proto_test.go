@@ -0,0 +1,70 @@
+package taints
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestTaintsProto(t *testing.T) {
+	taints := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: "bar", Effect: v1.TaintEffectNoExecute},
+	}
+
+	data, err := EncodeTaintsProto(taints)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	decoded, err := DecodeTaintsProto(data)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if !reflect.DeepEqual(taints, decoded) {
+		t.Errorf("expected taints %v, but got: %v", taints, decoded)
+	}
+
+	added, removed, err := ParseAny("protobuf", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if !reflect.DeepEqual(taints, added) || len(removed) > 0 {
+		t.Errorf("expected taints %v, but got: %v and %v", taints, added, removed)
+	}
+
+	invalid, err := EncodeTaintsProto([]v1.Taint{{Key: "foo", Effect: "Never"}})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if _, _, err := ParseAny("protobuf", bytes.NewReader(invalid)); err == nil {
+		t.Errorf("expected invalid taints to fail validation")
+	}
+	if _, err := DecodeTaintsProto([]byte{0xff}); err == nil {
+		t.Errorf("expected malformed data to fail decoding")
+	}
+}
+
+func TestNodesProto(t *testing.T) {
+	node := newNode("worker-1", v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule})
+
+	data, err := EncodeNodeProto(&node)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("k8s\x00")) {
+		t.Errorf("expected the Kubernetes protobuf envelope, but got: %q", data)
+	}
+	nodes, err := DecodeNodesProto(data)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != node.Name || !reflect.DeepEqual(node.Spec.Taints, nodes[0].Spec.Taints) {
+		t.Errorf("expected node %+v, but got: %+v", node, nodes)
+	}
+
+	if _, err := DecodeNodesProto([]byte("{}")); err == nil {
+		t.Errorf("expected JSON to fail decoding")
+	}
+}
@@ -0,0 +1,63 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// RemovalMatchMode is how a TaintRemoval selects the taints it removes.
+type RemovalMatchMode string
+
+const (
+	// MatchKey removes the taints with the key, whatever their effect, as '<key>-' does.
+	MatchKey RemovalMatchMode = "Key"
+	// MatchKeyAndEffect removes the taint with the key and effect, as '<key>:<effect>-' does.
+	MatchKeyAndEffect RemovalMatchMode = "KeyAndEffect"
+)
+
+// TaintRemoval is the intent of a removal spec. Unlike the v1.Taint returned by ParseTaints for
+// removals, it tells removing a key with any effect apart from removing a key with an empty
+// effect.
+type TaintRemoval struct {
+	Key string `json:"key"`
+	// Effect is the effect of the taints removed with MatchKeyAndEffect.
+	Effect v1.TaintEffect `json:"effect,omitempty"`
+	// Value is the value given in the spec, if any. Like with kubectl, it does not restrict the
+	// taints removed.
+	Value     string           `json:"value,omitempty"`
+	MatchMode RemovalMatchMode `json:"matchMode"`
+}
+
+// Matches reports whether the removal applies to the taint.
+func (r TaintRemoval) Matches(taint *v1.Taint) bool {
+	if r.MatchMode == MatchKey {
+		return r.Key == taint.Key
+	}
+	return r.Key == taint.Key && r.Effect == taint.Effect
+}
+
+// Taint returns the removal in the form returned by ParseTaints, a taint with the key, and the
+// effect if the removal matches it.
+func (r TaintRemoval) Taint() v1.Taint {
+	if r.MatchMode == MatchKey {
+		return v1.Taint{Key: r.Key}
+	}
+	return v1.Taint{Key: r.Key, Effect: r.Effect}
+}
+
+// removalTaints returns the removals in the form returned by ParseTaints.
+func removalTaints(removals []TaintRemoval) []v1.Taint {
+	if removals == nil {
+		return nil
+	}
+	taints := make([]v1.Taint, 0, len(removals))
+	for _, removal := range removals {
+		taints = append(taints, removal.Taint())
+	}
+	return taints
+}
+
+// lessRemoval orders removals like lessTaint orders their taints.
+func lessRemoval(a, b *TaintRemoval) bool {
+	ta, tb := a.Taint(), b.Taint()
+	return lessTaint(&ta, &tb)
+}
@@ -0,0 +1,45 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestTaintRemovals(t *testing.T) {
+	result, err := Parse([]string{"foo-", "bar=abc:NoSchedule-", "baz:NoExecute-"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expected := []TaintRemoval{
+		{Key: "foo", MatchMode: MatchKey},
+		{Key: "bar", Effect: v1.TaintEffectNoSchedule, Value: "abc", MatchMode: MatchKeyAndEffect},
+		{Key: "baz", Effect: v1.TaintEffectNoExecute, MatchMode: MatchKeyAndEffect},
+	}
+	if !reflect.DeepEqual(expected, result.TaintRemovals) {
+		t.Errorf("expected removals %+v, but got: %+v", expected, result.TaintRemovals)
+	}
+
+	taints := []v1.Taint{
+		{Key: "foo", Effect: v1.TaintEffectNoSchedule},
+		{Key: "foo", Effect: v1.TaintEffectNoExecute},
+		{Key: "bar", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+		{Key: "baz", Effect: v1.TaintEffectNoSchedule},
+	}
+	expectedMatches := [][]bool{
+		{true, true, false, false},
+		{false, false, true, false},
+		{false, false, false, false},
+	}
+	for i, removal := range result.TaintRemovals {
+		for j := range taints {
+			if matches := removal.Matches(&taints[j]); matches != expectedMatches[i][j] {
+				t.Errorf("expected removal %+v to match taint %v: %v, but got: %v", removal, taints[j].ToString(), expectedMatches[i][j], matches)
+			}
+		}
+		if legacy := removal.Taint(); legacy != result.Removals[i] {
+			t.Errorf("expected removal %+v as taint %v, but got: %v", removal, result.Removals[i], legacy)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package taints
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Report is a titled table rendered by RenderMarkdown and RenderHTML.
+type Report struct {
+	Title   string     `json:"title"`
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// DriftReport returns a report with a row per drifted node.
+func DriftReport(drifts []NodeDrift) Report {
+	report := Report{
+		Title:   "Taint drift",
+		Columns: []string{"Node", "Missing", "Changed", "Unwanted"},
+	}
+	for _, drift := range drifts {
+		changed := make([]string, 0, len(drift.Changed))
+		for _, planned := range drift.Changed {
+			from := planned.Taint
+			from.Value = planned.OldValue
+			changed = append(changed, defaultFormatter.Format(from)+" → "+defaultFormatter.Format(planned.Taint))
+		}
+		report.Rows = append(report.Rows, []string{
+			drift.Node,
+			formatTaintList(drift.Missing),
+			strings.Join(changed, ", "),
+			formatTaintList(drift.Unwanted),
+		})
+	}
+	return report
+}
+
+// StatsReport returns a report with a row per taint key and effect, with the most common first,
+// and a summary of the nodes in its title.
+func StatsReport(stats Stats) Report {
+	report := Report{
+		Title:   fmt.Sprintf("Taints of %d nodes (%d without taints)", stats.Nodes, stats.NodesWithoutTaints),
+		Columns: []string{"Taint", "Count"},
+	}
+	keys := make([]string, 0, len(stats.ByKeyAndEffect))
+	for key := range stats.ByKeyAndEffect {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if stats.ByKeyAndEffect[keys[i]] != stats.ByKeyAndEffect[keys[j]] {
+			return stats.ByKeyAndEffect[keys[i]] > stats.ByKeyAndEffect[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		report.Rows = append(report.Rows, []string{key, strconv.Itoa(stats.ByKeyAndEffect[key])})
+	}
+	return report
+}
+
+// effectOrder orders the effects of EffectReport from the most to the least disruptive.
+var effectOrder = map[v1.TaintEffect]int{
+	v1.TaintEffectNoExecute:        0,
+	v1.TaintEffectNoSchedule:       1,
+	v1.TaintEffectPreferNoSchedule: 2,
+}
+
+// EffectReport returns a report of the taints of a node with a row per effect, NoExecute first,
+// then NoSchedule, PreferNoSchedule and unknown effects by name.
+func EffectReport(node string, taints []v1.Taint) Report {
+	report := Report{
+		Title:   "Taints of " + node + " by effect",
+		Columns: []string{"Effect", "Taints"},
+	}
+	groups := GroupTaintsByEffect(taints)
+	effects := make([]v1.TaintEffect, 0, len(groups))
+	for effect := range groups {
+		effects = append(effects, effect)
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		oi, iKnown := effectOrder[effects[i]]
+		oj, jKnown := effectOrder[effects[j]]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return effects[i] < effects[j]
+	})
+	for _, effect := range effects {
+		report.Rows = append(report.Rows, []string{string(effect), formatTaintList(groups[effect])})
+	}
+	return report
+}
+
+func formatTaintList(taints []v1.Taint) string {
+	spec := make([]string, 0, len(taints))
+	for _, taint := range taints {
+		spec = append(spec, defaultFormatter.Format(taint))
+	}
+	return strings.Join(spec, ", ")
+}
+
+// RenderMarkdown writes the report as a Markdown heading and table, suitable for pull request
+// comments.
+func (r Report) RenderMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", r.Title)
+	if len(r.Rows) == 0 {
+		b.WriteString("Nothing to report.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	writeMarkdownRow(&b, r.Columns)
+	separators := make([]string, len(r.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(&b, separators)
+	for _, row := range r.Rows {
+		writeMarkdownRow(&b, row)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(strings.ReplaceAll(cell, "|", `\|`))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Rows}}
+<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>Nothing to report.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the report as a standalone HTML page, suitable for email.
+func (r Report) RenderHTML(w io.Writer) error {
+	return reportHTMLTemplate.Execute(w, r)
+}
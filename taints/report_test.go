@@ -0,0 +1,106 @@
+package taints
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+var testDrifts = []NodeDrift{
+	{
+		Node:    "worker-1",
+		Missing: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+	},
+	{
+		Node: "worker-2",
+		Changed: []PlannedAdd{
+			{Taint: v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}, Action: AddOverwrite, OldValue: "cpu"},
+		},
+		Unwanted: []v1.Taint{{Key: "maintenance", Effect: v1.TaintEffectNoExecute}},
+	},
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	cases := []struct {
+		name     string
+		report   Report
+		expected string
+	}{
+		{
+			name:     "no drift",
+			report:   DriftReport(nil),
+			expected: "### Taint drift\n\nNothing to report.\n",
+		},
+		{
+			name:   "drift",
+			report: DriftReport(testDrifts),
+			expected: "### Taint drift\n\n" +
+				"| Node | Missing | Changed | Unwanted |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| worker-1 | dedicated=gpu:NoSchedule |  |  |\n" +
+				"| worker-2 |  | dedicated=cpu:NoSchedule → dedicated=gpu:NoSchedule | maintenance:NoExecute |\n",
+		},
+		{
+			name: "stats",
+			report: StatsReport(ComputeStats([]v1.Node{
+				newNode("worker-1", v1.Taint{Key: "a", Effect: v1.TaintEffectNoSchedule}, v1.Taint{Key: "b", Effect: v1.TaintEffectNoSchedule}),
+				newNode("worker-2", v1.Taint{Key: "b", Effect: v1.TaintEffectNoSchedule}),
+				newNode("worker-3"),
+			})),
+			expected: "### Taints of 3 nodes (1 without taints)\n\n" +
+				"| Taint | Count |\n" +
+				"| --- | --- |\n" +
+				"| b:NoSchedule | 2 |\n" +
+				"| a:NoSchedule | 1 |\n",
+		},
+		{
+			name: "effects",
+			report: EffectReport("worker-1", []v1.Taint{
+				{Key: "a", Effect: v1.TaintEffectPreferNoSchedule},
+				{Key: "b", Effect: "Later"},
+				{Key: "c", Effect: v1.TaintEffectNoSchedule},
+				{Key: "d", Value: "x", Effect: v1.TaintEffectNoExecute},
+				{Key: "e", Effect: v1.TaintEffectNoSchedule},
+			}),
+			expected: "### Taints of worker-1 by effect\n\n" +
+				"| Effect | Taints |\n" +
+				"| --- | --- |\n" +
+				"| NoExecute | d=x:NoExecute |\n" +
+				"| NoSchedule | c:NoSchedule, e:NoSchedule |\n" +
+				"| PreferNoSchedule | a:PreferNoSchedule |\n" +
+				"| Later | b:Later |\n",
+		},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		if err := c.report.RenderMarkdown(&out); err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if out.String() != c.expected {
+			t.Errorf("[%s] expected\n%s\nbut got:\n%s", c.name, c.expected, out.String())
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	report := DriftReport(testDrifts)
+	report.Rows[0][0] = "<worker-1>"
+
+	var out bytes.Buffer
+	if err := report.RenderHTML(&out); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	for _, expected := range []string{
+		"<title>Taint drift</title>",
+		"<tr><th>Node</th><th>Missing</th><th>Changed</th><th>Unwanted</th></tr>",
+		"<td>&lt;worker-1&gt;</td>",
+		"<td>maintenance:NoExecute</td>",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected page to contain %q, but got:\n%s", expected, out.String())
+		}
+	}
+}
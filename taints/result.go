@@ -0,0 +1,96 @@
+package taints
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Result is the outcome of parsing a spec with Parse.
+type Result struct {
+	// Adds are the taints to be added, in the order of their specs.
+	Adds []v1.Taint `json:"adds,omitempty"`
+	// Removals are the taints to be removed, in the order of their specs.
+	Removals []v1.Taint `json:"removals,omitempty"`
+	// TaintRemovals are the intents of the removal specs, in the same order as Removals.
+	TaintRemovals []TaintRemoval `json:"taintRemovals,omitempty"`
+	// AddIndices are the indices of the specs of Adds: AddIndices[i] is the spec that added
+	// Adds[i]. Of specs adding the same taint, it is the one whose value was kept.
+	AddIndices []int `json:"addIndices,omitempty"`
+	// RemovalIndices are the indices of the specs of Removals: RemovalIndices[i] is the spec that
+	// removed Removals[i].
+	RemovalIndices []int `json:"removalIndices,omitempty"`
+	// Warnings are the non-fatal findings about the spec, also passed to the handler registered
+	// with WithWarningHandler.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Parse parses and validates a spec like ParseTaints, with its behavior adjusted by the given
+// options, and returns everything learned about it as a Result. New information about a parse is
+// added to Result rather than to the signature of ParseTaints.
+func Parse(spec []string, opts ...Option) (*Result, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	warn := o.warn
+	o.warn = func(warning string) {
+		result.Warnings = append(result.Warnings, warning)
+		warn(warning)
+	}
+
+	parsed, err := o.parseTaints(spec)
+	if err != nil {
+		o.hooks.OnValidateError(err)
+		return nil, err
+	}
+	result.Adds, result.AddIndices = parsed.taints, parsed.taintIndices
+	result.TaintRemovals, result.RemovalIndices = parsed.taintsToRemove, parsed.removalIndices
+	if o.sorted {
+		result.Adds, result.AddIndices = sortIndexed(result.Adds, result.AddIndices, lessTaintAndValue)
+		result.TaintRemovals, result.RemovalIndices = sortIndexed(result.TaintRemovals, result.RemovalIndices, lessRemoval)
+	}
+	result.Removals = removalTaints(result.TaintRemovals)
+	o.hooks.OnParse(result.Adds, result.Removals)
+	return result, nil
+}
+
+// Positions returns the positions in Adds and in Removals of the taints of the spec at the index,
+// such as for pointing from a spec to the taints it produced.
+func (r *Result) Positions(index int) (adds []int, removals []int) {
+	for pos, i := range r.AddIndices {
+		if i == index {
+			adds = append(adds, pos)
+		}
+	}
+	for pos, i := range r.RemovalIndices {
+		if i == index {
+			removals = append(removals, pos)
+		}
+	}
+	return adds, removals
+}
+
+// sortIndexed returns the items stably sorted by less, along with the indices reordered in the
+// same way, so that each index stays with its item.
+func sortIndexed[T any](items []T, indices []int, less func(a, b *T) bool) ([]T, []int) {
+	if items == nil {
+		return nil, nil
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(&items[order[i]], &items[order[j]])
+	})
+	sortedItems := make([]T, 0, len(items))
+	sortedIndices := make([]int, 0, len(indices))
+	for _, i := range order {
+		sortedItems = append(sortedItems, items[i])
+		sortedIndices = append(sortedIndices, indices[i])
+	}
+	return sortedItems, sortedIndices
+}
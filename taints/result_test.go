@@ -0,0 +1,122 @@
+package taints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name           string
+		spec           []string
+		opts           []Option
+		expectedResult *Result
+		expectedErr    bool
+	}{
+		{
+			name:        "invalid spec",
+			spec:        []string{"foo"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid option",
+			spec:        []string{"foo:NoSchedule"},
+			opts:        []Option{WithTargetVersion("1.0")},
+			expectedErr: true,
+		},
+		{
+			name: "adds and removals",
+			spec: []string{"foo=abc:NoSchedule", "bar-"},
+			expectedResult: &Result{
+				Adds:           []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}},
+				Removals:       []v1.Taint{{Key: "bar"}},
+				TaintRemovals:  []TaintRemoval{{Key: "bar", MatchMode: MatchKey}},
+				AddIndices:     []int{0},
+				RemovalIndices: []int{1},
+			},
+		},
+		{
+			name: "sorted indices",
+			spec: []string{"foo=abc:NoSchedule", "qux-", "bar:NoExecute", "baz:NoSchedule-"},
+			opts: []Option{WithSortedOutput()},
+			expectedResult: &Result{
+				Adds: []v1.Taint{
+					{Key: "bar", Effect: v1.TaintEffectNoExecute},
+					{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				},
+				Removals: []v1.Taint{{Key: "baz", Effect: v1.TaintEffectNoSchedule}, {Key: "qux"}},
+				TaintRemovals: []TaintRemoval{
+					{Key: "baz", Effect: v1.TaintEffectNoSchedule, MatchMode: MatchKeyAndEffect},
+					{Key: "qux", MatchMode: MatchKey},
+				},
+				AddIndices:     []int{2, 0},
+				RemovalIndices: []int{3, 1},
+			},
+		},
+		{
+			name: "duplicates keeping the last value",
+			spec: []string{"foo=abc:NoSchedule", "bar:NoExecute", "foo=xyz:NoSchedule"},
+			opts: []Option{WithDuplicates(DuplicatesKeepLast)},
+			expectedResult: &Result{
+				Adds: []v1.Taint{
+					{Key: "foo", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+					{Key: "bar", Effect: v1.TaintEffectNoExecute},
+				},
+				AddIndices: []int{2, 1},
+				Warnings:   []string{"duplicated taints with the same key and effect: foo:NoSchedule (specs 0, 2)"},
+			},
+		},
+		{
+			name: "warnings",
+			spec: []string{"foo=abc:Later"},
+			opts: []Option{WithUnknownEffects()},
+			expectedResult: &Result{
+				Adds:       []v1.Taint{{Key: "foo", Value: "abc", Effect: "Later"}},
+				AddIndices: []int{0},
+				Warnings:   []string{"unknown taint effect: Later, passing it through unchanged"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		result, err := Parse(c.spec, c.opts...)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedResult, result) {
+			t.Errorf("[%s] expected result %+v, but got: %+v", c.name, c.expectedResult, result)
+		}
+	}
+}
+
+func TestResultPositions(t *testing.T) {
+	bundles, err := LoadBundles(strings.NewReader(testBundles))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	result, err := Parse([]string{"qux-", "@gpu", "foo=abc:NoSchedule"}, WithBundles(bundles))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	adds, removals := result.Positions(1)
+	if len(adds) == 0 || len(removals) > 0 {
+		t.Errorf("expected the bundle to produce adds only, but got: %v and %v", adds, removals)
+	}
+	for _, pos := range adds {
+		if result.AddIndices[pos] != 1 {
+			t.Errorf("expected add %d to come from spec 1, but got: %d", pos, result.AddIndices[pos])
+		}
+	}
+	if adds, removals := result.Positions(0); len(adds) > 0 || !reflect.DeepEqual([]int{0}, removals) {
+		t.Errorf("expected spec 0 to produce removal 0, but got: %v and %v", adds, removals)
+	}
+	if adds, removals := result.Positions(5); adds != nil || removals != nil {
+		t.Errorf("expected no positions for a missing spec, but got: %v and %v", adds, removals)
+	}
+}
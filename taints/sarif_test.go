@@ -0,0 +1,68 @@
+package taints
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	findings := Lint([]string{"example.com/foo:NoSchedule", "foo=bar", "example.com/bar=yes:NoExecute"}, NoExecuteAllowList())
+
+	var out bytes.Buffer
+	if err := WriteSARIF(&out, "nodes/taints.txt", findings); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("expected valid JSON, but got: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected a single SARIF 2.1.0 run, but got: %+v", log)
+	}
+
+	expectedRules := []sarifRule{{ID: RuleInvalidSpec}, {ID: RulePolicy}, {ID: RuleBooleanValue}}
+	if !reflect.DeepEqual(expectedRules, log.Runs[0].Tool.Driver.Rules) {
+		t.Errorf("expected rules %v, but got: %v", expectedRules, log.Runs[0].Tool.Driver.Rules)
+	}
+
+	var results []string
+	for _, result := range log.Runs[0].Results {
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != "nodes/taints.txt" {
+			t.Errorf("expected results in nodes/taints.txt, but got: %v", location.ArtifactLocation.URI)
+		}
+		results = append(results, fmt.Sprintf("%s@%d:%s", result.RuleID, location.Region.StartLine, result.Level))
+	}
+	expectedResults := []string{"invalid-spec@2:error", "policy-violation@3:error", "boolean-value@3:warning"}
+	if !reflect.DeepEqual(expectedResults, results) {
+		t.Errorf("expected results %v, but got: %v", expectedResults, results)
+	}
+}
+
+func TestWriteSARIFTaintsFile(t *testing.T) {
+	file := "# taints of the GPU pool\n\nexample.com/foo:NoSchedule\nfoo=bar  # missing effect\n"
+	findings, err := LintTaintsFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Index != 1 || findings[0].Line != 4 {
+		t.Fatalf("expected a finding for spec 1 at line 4, but got: %+v", findings)
+	}
+
+	var out bytes.Buffer
+	if err := WriteSARIF(&out, "nodes/gpu.taints", findings); err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("expected valid JSON, but got: %v", err)
+	}
+	if line := log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine; line != 4 {
+		t.Errorf("expected the result at line 4, but got: %d", line)
+	}
+}
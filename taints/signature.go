@@ -0,0 +1,40 @@
+package taints
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Signature identifies a set of taints regardless of their order and TimeAdded. It is the
+// canonical specs of the taints, sorted and joined by commas, and empty for no taints.
+type Signature string
+
+// TaintSignature returns the signature of the taints.
+func TaintSignature(taints []v1.Taint) Signature {
+	spec := make([]string, 0, len(taints))
+	for _, taint := range sortedTaints(taints) {
+		spec = append(spec, defaultFormatter.Format(taint))
+	}
+	return Signature(strings.Join(spec, ","))
+}
+
+// Hash returns a short, stable hash of the signature, for use where the signature itself would
+// be too long, such as in labels or file names.
+func (s Signature) Hash() string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// GroupNodesByTaintSignature groups the names of the nodes by the signature of their taints.
+// Node names keep the order of the nodes within each group.
+func GroupNodesByTaintSignature(nodes []v1.Node) map[Signature][]string {
+	groups := map[Signature][]string{}
+	for i := range nodes {
+		signature := TaintSignature(nodes[i].Spec.Taints)
+		groups[signature] = append(groups[signature], nodes[i].Name)
+	}
+	return groups
+}
@@ -0,0 +1,44 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGroupNodesByTaintSignature(t *testing.T) {
+	timeAdded := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	nodes := []v1.Node{
+		newNode("gpu-1",
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "spot", Effect: v1.TaintEffectNoExecute}),
+		newNode("plain-1"),
+		newNode("gpu-2",
+			v1.Taint{Key: "spot", Effect: v1.TaintEffectNoExecute, TimeAdded: &timeAdded},
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}),
+		newNode("plain-2"),
+	}
+
+	expected := map[Signature][]string{
+		"dedicated=gpu:NoSchedule,spot:NoExecute": {"gpu-1", "gpu-2"},
+		"": {"plain-1", "plain-2"},
+	}
+	groups := GroupNodesByTaintSignature(nodes)
+	if !reflect.DeepEqual(expected, groups) {
+		t.Errorf("expected groups %v, but got: %v", expected, groups)
+	}
+}
+
+func TestSignatureHash(t *testing.T) {
+	a := TaintSignature([]v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}})
+	b := TaintSignature([]v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoExecute}})
+	if len(a.Hash()) != 12 {
+		t.Errorf("expected a 12 character hash, but got: %v", a.Hash())
+	}
+	if a.Hash() != a.Hash() || a.Hash() == b.Hash() {
+		t.Errorf("expected hashes to be stable and distinct, but got: %v and %v", a.Hash(), b.Hash())
+	}
+}
@@ -0,0 +1,79 @@
+package taints
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+)
+
+// ReadinessPredicate reports whether a node has finished initializing.
+type ReadinessPredicate func(ctx context.Context, node *v1.Node) (bool, error)
+
+// ConditionReady returns a ReadinessPredicate passing once the node condition has had the status
+// for at least the grace period.
+func ConditionReady(condition v1.NodeConditionType, status v1.ConditionStatus, gracePeriod time.Duration) ReadinessPredicate {
+	return ConditionReadyWithClock(clock.RealClock{}, condition, status, gracePeriod)
+}
+
+// ConditionReadyWithClock behaves like ConditionReady, measuring the grace period with the clock.
+func ConditionReadyWithClock(clk clock.PassiveClock, condition v1.NodeConditionType, status v1.ConditionStatus, gracePeriod time.Duration) ReadinessPredicate {
+	return func(ctx context.Context, node *v1.Node) (bool, error) {
+		return conditionHeld(node, condition, status, gracePeriod, clk.Now()), nil
+	}
+}
+
+// StartupTaintGate manages a startup taint, such as 'node.example.com/uninitialized:NoSchedule',
+// that nodes register with when joining and that is removed once they are ready.
+type StartupTaintGate struct {
+	taint v1.Taint
+	ready ReadinessPredicate
+}
+
+// NewStartupTaintGate returns a StartupTaintGate for the taint spec, removing the taint once the
+// predicate passes.
+func NewStartupTaintGate(spec string, ready ReadinessPredicate) (*StartupTaintGate, error) {
+	taints, _, err := ParseTaints([]string{spec})
+	if err != nil {
+		return nil, err
+	}
+	if len(taints) == 0 {
+		return nil, fmt.Errorf("invalid startup taint: %v removes a taint", spec)
+	}
+	return &StartupTaintGate{taint: taints[0], ready: ready}, nil
+}
+
+// Taint returns the startup taint.
+func (g *StartupTaintGate) Taint() v1.Taint {
+	return g.taint
+}
+
+// RegisterWithTaints returns the value of the --register-with-taints kubelet flag applying the
+// startup taint when the node joins.
+func (g *StartupTaintGate) RegisterWithTaints() string {
+	return defaultFormatter.Format(g.taint)
+}
+
+// Evaluate returns the changes removing the startup taint from the node once the predicate
+// passes. The diff is empty while the node is not ready, or if it does not have the taint.
+func (g *StartupTaintGate) Evaluate(ctx context.Context, node *v1.Node) (TaintDiff, error) {
+	if indexOfTaint(node.Spec.Taints, &g.taint) < 0 {
+		return DiffTaints(node.Spec.Taints, node.Spec.Taints), nil
+	}
+	ready, err := g.ready(ctx, node)
+	if err != nil {
+		return TaintDiff{}, fmt.Errorf("failed to check readiness of node %v: %v", node.Name, err)
+	}
+	desired := node.Spec.Taints
+	if ready {
+		desired = nil
+		for _, taint := range node.Spec.Taints {
+			if !taint.MatchTaint(&g.taint) {
+				desired = append(desired, taint)
+			}
+		}
+	}
+	return DiffTaints(node.Spec.Taints, desired), nil
+}
@@ -0,0 +1,118 @@
+package taints
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestStartupTaintGate(t *testing.T) {
+	startup := v1.Taint{Key: "node.example.com/uninitialized", Effect: v1.TaintEffectNoSchedule}
+	unrelated := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+
+	cases := []struct {
+		name            string
+		node            v1.Node
+		ready           ReadinessPredicate
+		expectedRemoved []v1.Taint
+		expectedErr     bool
+	}{
+		{
+			name:            "ready",
+			node:            newNode("worker-1", startup, unrelated),
+			ready:           func(context.Context, *v1.Node) (bool, error) { return true, nil },
+			expectedRemoved: []v1.Taint{startup},
+		},
+		{
+			name:  "not ready",
+			node:  newNode("worker-1", startup),
+			ready: func(context.Context, *v1.Node) (bool, error) { return false, nil },
+		},
+		{
+			name:  "already initialized",
+			node:  newNode("worker-1", unrelated),
+			ready: func(context.Context, *v1.Node) (bool, error) { panic("unexpected readiness check") },
+		},
+		{
+			name:        "readiness check failing",
+			node:        newNode("worker-1", startup),
+			ready:       func(context.Context, *v1.Node) (bool, error) { return false, errors.New("unavailable") },
+			expectedErr: true,
+		},
+		{
+			name: "condition ready",
+			node: func() v1.Node {
+				node := newNode("worker-1", startup)
+				node.Status.Conditions = []v1.NodeCondition{{
+					Type:               "NetworkReady",
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+				}}
+				return node
+			}(),
+			ready:           ConditionReady("NetworkReady", v1.ConditionTrue, 30*time.Second),
+			expectedRemoved: []v1.Taint{startup},
+		},
+	}
+
+	for _, c := range cases {
+		gate, err := NewStartupTaintGate("node.example.com/uninitialized:NoSchedule", c.ready)
+		if err != nil {
+			t.Fatalf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		diff, err := gate.Evaluate(context.Background(), &c.node)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedRemoved, diff.Removed) {
+			t.Errorf("[%s] expected removed taints %v, but got: %v", c.name, c.expectedRemoved, diff.Removed)
+		}
+		if len(diff.Added) > 0 || len(diff.Changed) > 0 {
+			t.Errorf("[%s] expected no added or changed taints, but got: %+v", c.name, diff)
+		}
+	}
+}
+
+func TestNewStartupTaintGate(t *testing.T) {
+	gate, err := NewStartupTaintGate("node.example.com/uninitialized=true:NoSchedule", nil)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if flag := gate.RegisterWithTaints(); flag != "node.example.com/uninitialized=true:NoSchedule" {
+		t.Errorf("expected flag value node.example.com/uninitialized=true:NoSchedule, but got: %v", flag)
+	}
+	for _, spec := range []string{"node.example.com/uninitialized", "node.example.com/uninitialized:NoSchedule-"} {
+		if _, err := NewStartupTaintGate(spec, nil); err == nil {
+			t.Errorf("expected error for spec %v, but got nothing", spec)
+		}
+	}
+}
+
+func TestConditionReadyWithClock(t *testing.T) {
+	transition := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	node := newNode("worker-1")
+	node.Status.Conditions = []v1.NodeCondition{{
+		Type:               "NetworkReady",
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(transition),
+	}}
+
+	clk := testingclock.NewFakePassiveClock(transition.Add(10 * time.Second))
+	ready := ConditionReadyWithClock(clk, "NetworkReady", v1.ConditionTrue, 30*time.Second)
+	if ok, _ := ready(context.Background(), &node); ok {
+		t.Errorf("expected node not to be ready within the grace period")
+	}
+	clk.SetTime(transition.Add(30 * time.Second))
+	if ok, _ := ready(context.Background(), &node); !ok {
+		t.Errorf("expected node to be ready after the grace period")
+	}
+}
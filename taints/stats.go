@@ -0,0 +1,103 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeTaint is a taint of a named node.
+type NodeTaint struct {
+	Node  string   `json:"node"`
+	Taint v1.Taint `json:"taint"`
+}
+
+// Stats aggregates the taints of a set of nodes. Counts are numbers of taints.
+type Stats struct {
+	Nodes              int                    `json:"nodes"`
+	NodesWithoutTaints int                    `json:"nodesWithoutTaints"`
+	ByKey              map[string]int         `json:"byKey"`
+	ByEffect           map[v1.TaintEffect]int `json:"byEffect"`
+	// ByKeyAndEffect is keyed by '<key>:<effect>'.
+	ByKeyAndEffect map[string]int `json:"byKeyAndEffect"`
+	// OldestNoExecute is the NoExecute taint with the earliest TimeAdded, if any has one.
+	OldestNoExecute *NodeTaint `json:"oldestNoExecute,omitempty"`
+}
+
+// ComputeStats aggregates the taints of the nodes.
+func ComputeStats(nodes []v1.Node) Stats {
+	stats := Stats{
+		Nodes:          len(nodes),
+		ByKey:          map[string]int{},
+		ByEffect:       map[v1.TaintEffect]int{},
+		ByKeyAndEffect: map[string]int{},
+	}
+	for i := range nodes {
+		if len(nodes[i].Spec.Taints) == 0 {
+			stats.NodesWithoutTaints++
+		}
+		for _, taint := range nodes[i].Spec.Taints {
+			stats.ByKey[taint.Key]++
+			stats.ByEffect[taint.Effect]++
+			stats.ByKeyAndEffect[taint.Key+":"+string(taint.Effect)]++
+
+			if taint.Effect != v1.TaintEffectNoExecute || taint.TimeAdded == nil {
+				continue
+			}
+			if stats.OldestNoExecute == nil || taint.TimeAdded.Before(stats.OldestNoExecute.Taint.TimeAdded) {
+				stats.OldestNoExecute = &NodeTaint{Node: nodes[i].Name, Taint: taint}
+			}
+		}
+	}
+	return stats
+}
+
+// GroupTaintsByEffect returns the taints by effect, each group in the order of the taints.
+func GroupTaintsByEffect(taints []v1.Taint) map[v1.TaintEffect][]v1.Taint {
+	groups := map[v1.TaintEffect][]v1.Taint{}
+	for _, taint := range taints {
+		groups[taint.Effect] = append(groups[taint.Effect], taint)
+	}
+	return groups
+}
+
+// CommonTaints returns the taints every node has, with the same key, value and effect, in the
+// order of the first node. TimeAdded is ignored.
+func CommonTaints(nodes []v1.Node) []v1.Taint {
+	if len(nodes) == 0 {
+		return nil
+	}
+	var common []v1.Taint
+	for _, taint := range nodes[0].Spec.Taints {
+		shared := true
+		for i := range nodes[1:] {
+			if !hasTaint(nodes[1+i].Spec.Taints, &taint) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			common = append(common, taint)
+		}
+	}
+	return common
+}
+
+// UncommonTaints returns the taints of the nodes that not every node has, the complement of
+// CommonTaints, in the order of the nodes and their taints.
+func UncommonTaints(nodes []v1.Node) []NodeTaint {
+	common := CommonTaints(nodes)
+	var uncommon []NodeTaint
+	for i := range nodes {
+		for _, taint := range nodes[i].Spec.Taints {
+			if !hasTaint(common, &taint) {
+				uncommon = append(uncommon, NodeTaint{Node: nodes[i].Name, Taint: taint})
+			}
+		}
+	}
+	return uncommon
+}
+
+// hasTaint reports whether one of the taints has the key, value and effect of the given one.
+func hasTaint(taints []v1.Taint, taint *v1.Taint) bool {
+	i := indexOfTaint(taints, taint)
+	return i >= 0 && taints[i].Value == taint.Value
+}
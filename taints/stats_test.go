@@ -0,0 +1,92 @@
+package taints
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeStats(t *testing.T) {
+	older := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	nodes := []v1.Node{
+		newNode("worker-1",
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute, TimeAdded: &newer}),
+		newNode("worker-2",
+			v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &older}),
+		newNode("worker-3"),
+	}
+
+	stats := ComputeStats(nodes)
+	expected := Stats{
+		Nodes:              3,
+		NodesWithoutTaints: 1,
+		ByKey:              map[string]int{"dedicated": 2, v1.TaintNodeUnreachable: 1},
+		ByEffect:           map[v1.TaintEffect]int{v1.TaintEffectNoSchedule: 1, v1.TaintEffectNoExecute: 2},
+		ByKeyAndEffect: map[string]int{
+			"dedicated:NoSchedule":                     1,
+			"dedicated:NoExecute":                      1,
+			"node.kubernetes.io/unreachable:NoExecute": 1,
+		},
+		OldestNoExecute: &NodeTaint{
+			Node:  "worker-2",
+			Taint: v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &older},
+		},
+	}
+	if !reflect.DeepEqual(expected, stats) {
+		t.Errorf("expected stats %+v, but got: %+v", expected, stats)
+	}
+	if _, err := json.Marshal(stats); err != nil {
+		t.Errorf("expected stats to be serializable, but got: %v", err)
+	}
+}
+
+func TestGroupTaintsByEffect(t *testing.T) {
+	taints := []v1.Taint{
+		{Key: "a", Effect: v1.TaintEffectNoSchedule},
+		{Key: "b", Effect: v1.TaintEffectNoExecute},
+		{Key: "c", Effect: v1.TaintEffectNoSchedule},
+	}
+	expected := map[v1.TaintEffect][]v1.Taint{
+		v1.TaintEffectNoSchedule: {taints[0], taints[2]},
+		v1.TaintEffectNoExecute:  {taints[1]},
+	}
+	if groups := GroupTaintsByEffect(taints); !reflect.DeepEqual(expected, groups) {
+		t.Errorf("expected groups %v, but got: %v", expected, groups)
+	}
+}
+
+func TestCommonTaints(t *testing.T) {
+	gpu := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	cpu := v1.Taint{Key: "dedicated", Value: "cpu", Effect: v1.TaintEffectNoSchedule}
+	spot := v1.Taint{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}
+	maintenance := v1.Taint{Key: "maintenance", Effect: v1.TaintEffectNoExecute}
+	addedSpot := spot
+	addedSpot.TimeAdded = &metav1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	nodes := []v1.Node{
+		newNode("worker-1", gpu, spot),
+		newNode("worker-2", addedSpot, gpu, maintenance),
+		newNode("worker-3", spot, cpu),
+	}
+	if common := CommonTaints(nodes); !reflect.DeepEqual([]v1.Taint{spot}, common) {
+		t.Errorf("expected common taints %v, but got: %v", []v1.Taint{spot}, common)
+	}
+	expectedUncommon := []NodeTaint{
+		{Node: "worker-1", Taint: gpu},
+		{Node: "worker-2", Taint: gpu},
+		{Node: "worker-2", Taint: maintenance},
+		{Node: "worker-3", Taint: cpu},
+	}
+	if uncommon := UncommonTaints(nodes); !reflect.DeepEqual(expectedUncommon, uncommon) {
+		t.Errorf("expected uncommon taints %v, but got: %v", expectedUncommon, uncommon)
+	}
+	if common := CommonTaints(nil); common != nil {
+		t.Errorf("expected no common taints without nodes, but got: %v", common)
+	}
+}
@@ -0,0 +1,188 @@
+package taints
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ScannedSpec is a spec read by a TaintScanner.
+type ScannedSpec struct {
+	// Index is the position of the line of the spec in the input, starting at 0.
+	Index int
+	Spec  string
+	// Adds and Removals are the taints added and removed by the spec.
+	Adds     []v1.Taint
+	Removals []v1.Taint
+	// Warnings are the non-fatal findings about the spec, also passed to the handler registered
+	// with WithWarningHandler.
+	Warnings []string
+}
+
+// TaintScanner reads specs one line at a time, returned by ParseTaintsFromReader.
+type TaintScanner struct {
+	scanner *bufio.Scanner
+	o       *options
+	index   int
+	// comments strips '#' comments and surrounding white space from lines.
+	comments bool
+	// seen holds the index of the spec adding each key and effect, for rejecting duplicates.
+	seen map[v1.Taint]int
+	// added are the taints added by the specs read so far, for warning about unknown removals.
+	added []v1.Taint
+	// warnings are those of the spec being read.
+	warnings []string
+	spec     ScannedSpec
+	err      error
+}
+
+// ParseTaintsFromReader returns a scanner parsing one spec per line of r, skipping blank lines,
+// for inputs too large to be read into a spec list first. Each spec is validated like by
+// ParseTaintsWithOptions with the options as soon as it is read, including that it does not add
+// a taint added by an earlier spec, and hooks are notified of each spec. As earlier specs have
+// already been returned, some options only see the specs read so far:
+//
+//   - taints added by an earlier spec are rejected regardless of WithDuplicates,
+//   - WithKnownKeys warns about removals of keys that only a later spec adds,
+//   - WithSortedOutput has no effect, as specs are returned in the order they are read.
+//
+// For example:
+//
+//	scanner := ParseTaintsFromReader(os.Stdin)
+//	for scanner.Scan() {
+//		spec := scanner.Spec()
+//		...
+//	}
+//	if err := scanner.Err(); err != nil {
+//		...
+//	}
+func ParseTaintsFromReader(r io.Reader, opts ...Option) *TaintScanner {
+	s := &TaintScanner{scanner: bufio.NewScanner(r), index: -1, seen: map[v1.Taint]int{}}
+	s.o, s.err = newOptions(opts)
+	if s.err != nil {
+		return s
+	}
+	warn := s.o.warn
+	s.o.warn = func(warning string) {
+		s.warnings = append(s.warnings, warning)
+		warn(warning)
+	}
+	return s
+}
+
+// Scan reads the next spec, which is then available through Spec. It returns false at the end of
+// the input or at the first invalid spec, which Err then returns.
+func (s *TaintScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.scanner.Scan() {
+		s.index++
+		line := s.scanner.Text()
+		if s.comments {
+			line = stripComment(line)
+		}
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		s.warnings = nil
+		taints, taintsToRemove, err := s.o.parseEntry(line)
+		if err == nil {
+			err = s.checkDuplicates(taints)
+		}
+		if err != nil {
+			s.o.localize(err)
+			s.o.hooks.OnValidateError(err)
+			s.err = &ParseError{Index: s.index, Spec: line, Err: err}
+			return false
+		}
+		s.added = append(s.added, taints...)
+		s.o.warnUnknownRemovals(s.added, taintsToRemove)
+		removals := removalTaints(taintsToRemove)
+		s.o.hooks.OnParse(taints, removals)
+		s.spec = ScannedSpec{Index: s.index, Spec: line, Adds: taints, Removals: removals, Warnings: s.warnings}
+		return true
+	}
+	s.err = s.scanner.Err()
+	return false
+}
+
+// checkDuplicates rejects taints added by an earlier spec.
+func (s *TaintScanner) checkDuplicates(taints []v1.Taint) error {
+	for _, taint := range taints {
+		id := v1.Taint{Key: taint.Key, Effect: taint.Effect}
+		if first, ok := s.seen[id]; ok {
+			return &DuplicateTaintError{
+				Duplicates: []DuplicateTaint{{Key: id.Key, Effect: id.Effect, Indices: []int{first, s.index}}},
+				messages:   s.o.messages,
+			}
+		}
+		s.seen[id] = s.index
+	}
+	return nil
+}
+
+// Spec returns the spec read by the last call to Scan.
+func (s *TaintScanner) Spec() ScannedSpec {
+	return s.spec
+}
+
+// Err returns the error that stopped Scan: a *ParseError for an invalid spec, or the error of
+// the reader or of the options. It returns nil at the end of the input.
+func (s *TaintScanner) Err() error {
+	return s.err
+}
+
+// ParseTaintsFile parses a taints file, which holds one spec per line like ParseTaintsFromReader
+// reads, where '#' starts a comment running to the end of the line:
+//
+//	# GPU nodes only run GPU workloads
+//	nvidia.com/gpu=present:NoSchedule
+//	spot:PreferNoSchedule  # preemptible capacity
+//
+// Errors name the file and the line of the invalid spec.
+func ParseTaintsFile(path string) ([]v1.Taint, []v1.Taint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	taints, taintsToRemove, err := readTaintsFile(f)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			return nil, nil, fmt.Errorf("%v:%d: %w", path, parseErr.Index+1, parseErr.Err)
+		}
+		return nil, nil, fmt.Errorf("failed to read %v: %w", path, err)
+	}
+	return taints, taintsToRemove, nil
+}
+
+// readTaintsFile parses a taints file read from r. Its error is a *ParseError for an invalid spec,
+// whose index is that of its line.
+func readTaintsFile(r io.Reader) ([]v1.Taint, []v1.Taint, error) {
+	var taints, taintsToRemove []v1.Taint
+	scanner := ParseTaintsFromReader(r)
+	scanner.comments = true
+	for scanner.Scan() {
+		spec := scanner.Spec()
+		taints = append(taints, spec.Adds...)
+		taintsToRemove = append(taintsToRemove, spec.Removals...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return taints, taintsToRemove, nil
+}
+
+// stripComment returns a line of a taints file without its comment and surrounding white space.
+func stripComment(line string) string {
+	line, _, _ = strings.Cut(line, "#")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,199 @@
+package taints
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParseTaintsFromReader(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         string
+		opts          []Option
+		expectedSpecs []ScannedSpec
+		expectedIndex int
+		expectedErr   error
+	}{
+		{
+			name:  "empty input",
+			input: "",
+		},
+		{
+			name:  "specs and blank lines",
+			input: "foo=abc:NoSchedule\n\n  \nbar:NoExecute-\nqux-\n",
+			expectedSpecs: []ScannedSpec{
+				{Index: 0, Spec: "foo=abc:NoSchedule", Adds: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}}},
+				{Index: 3, Spec: "bar:NoExecute-", Removals: []v1.Taint{{Key: "bar", Effect: v1.TaintEffectNoExecute}}},
+				{Index: 4, Spec: "qux-", Removals: []v1.Taint{{Key: "qux"}}},
+			},
+		},
+		{
+			name:  "invalid spec",
+			input: "foo=abc:NoSchedule\nbar\nqux-",
+			expectedSpecs: []ScannedSpec{
+				{Index: 0, Spec: "foo=abc:NoSchedule", Adds: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}}},
+			},
+			expectedIndex: 1,
+			expectedErr:   ErrMissingEffect,
+		},
+		{
+			name:  "duplicate",
+			input: "foo=abc:NoSchedule\nfoo=xyz:NoSchedule",
+			opts:  []Option{WithDuplicates(DuplicatesKeepFirst)},
+			expectedSpecs: []ScannedSpec{
+				{Index: 0, Spec: "foo=abc:NoSchedule", Adds: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}}},
+			},
+			expectedIndex: 1,
+			expectedErr:   ErrDuplicateTaint,
+		},
+		{
+			name:  "options",
+			input: " foo=abc:noschedule ",
+			opts:  []Option{WithTrimSpace(), WithCaseInsensitiveEffects()},
+			expectedSpecs: []ScannedSpec{
+				{Index: 0, Spec: " foo=abc:noschedule ", Adds: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		scanner := ParseTaintsFromReader(strings.NewReader(c.input), c.opts...)
+		var specs []ScannedSpec
+		for scanner.Scan() {
+			specs = append(specs, scanner.Spec())
+		}
+		if !reflect.DeepEqual(c.expectedSpecs, specs) {
+			t.Errorf("[%s] expected specs %+v, but got: %+v", c.name, c.expectedSpecs, specs)
+		}
+		err := scanner.Err()
+		if c.expectedErr == nil {
+			if err != nil {
+				t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+			}
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Index != c.expectedIndex || !errors.Is(err, c.expectedErr) {
+			t.Errorf("[%s] expected error %v for spec %d, but got: %v", c.name, c.expectedErr, c.expectedIndex, err)
+		}
+	}
+
+	scanner := ParseTaintsFromReader(strings.NewReader("foo:NoSchedule"), WithTargetVersion("1.0"))
+	if scanner.Scan() || scanner.Err() == nil {
+		t.Errorf("expected an invalid option to stop the scanner")
+	}
+}
+
+func TestTaintScannerWarningsAndHooks(t *testing.T) {
+	var handled []string
+	hooks := &countingHooks{}
+	input := "dedicated=gpu:NoSchedule\ndedicted-\ndedicated-\nfoo"
+	scanner := ParseTaintsFromReader(strings.NewReader(input),
+		WithKnownKeys("spot"), WithHooks(hooks), WithWarningHandler(func(w string) { handled = append(handled, w) }))
+
+	var warnings [][]string
+	for scanner.Scan() {
+		warnings = append(warnings, scanner.Spec().Warnings)
+	}
+	expected := [][]string{nil, {"removal of unknown taint key: dedicted, did you mean dedicated?"}, nil}
+	if !reflect.DeepEqual(expected, warnings) {
+		t.Errorf("expected warnings %v, but got: %v", expected, warnings)
+	}
+	if !reflect.DeepEqual(expected[1], handled) {
+		t.Errorf("expected the handler to receive %v, but got: %v", expected[1], handled)
+	}
+	if hooks.parsed != 3 || hooks.failed != 1 {
+		t.Errorf("expected 3 parsed specs and 1 failed spec, but got: %+v", hooks)
+	}
+}
+
+func TestParseTaintsFile(t *testing.T) {
+	cases := []struct {
+		name                   string
+		content                string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            string
+	}{
+		{
+			name: "comments and blank lines",
+			content: `# GPU nodes only run GPU workloads
+nvidia.com/gpu=present:NoSchedule
+
+  spot:PreferNoSchedule  # preemptible capacity
+# retired
+legacy-
+`,
+			expectedTaints: []v1.Taint{
+				{Key: "nvidia.com/gpu", Value: "present", Effect: v1.TaintEffectNoSchedule},
+				{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+			},
+			expectedTaintsToRemove: []v1.Taint{{Key: "legacy"}},
+		},
+		{
+			name:        "invalid spec",
+			content:     "# header\nfoo=abc:NoSchedule\nbar # no effect\n",
+			expectedErr: "taints:3: invalid taint spec: bar",
+		},
+	}
+
+	for _, c := range cases {
+		path := filepath.Join(t.TempDir(), "taints")
+		if err := os.WriteFile(path, []byte(c.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		taints, taintsToRemove, err := ParseTaintsFile(path)
+		if len(c.expectedErr) > 0 {
+			if err == nil || err.Error() != filepath.Join(filepath.Dir(path), c.expectedErr) {
+				t.Errorf("[%s] expected error %q, but got: %v", c.name, c.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+
+	if _, _, err := ParseTaintsFile(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file error, but got: %v", err)
+	}
+}
+
+func TestTaintsFileEntryPointsAgree(t *testing.T) {
+	content := "# GPU nodes\nnvidia.com/gpu=present:NoSchedule  # exclusive\n\nlegacy-\n"
+	path := filepath.Join(t.TempDir(), "gpu.taints")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	taints, taintsToRemove, err := ParseTaintsFile(path)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	format, ok := FormatForFile(path)
+	if !ok {
+		t.Fatalf("expected a format for %v", path)
+	}
+	decodedTaints, decodedTaintsToRemove, err := ParseAny(format, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if !reflect.DeepEqual(taints, decodedTaints) || !reflect.DeepEqual(taintsToRemove, decodedTaintsToRemove) {
+		t.Errorf("expected ParseAny to return %v and %v, but got: %v and %v", taints, taintsToRemove, decodedTaints, decodedTaintsToRemove)
+	}
+	if detectedTaints, _, err := ParseAny("", strings.NewReader(content)); err != nil || !reflect.DeepEqual(taints, detectedTaints) {
+		t.Errorf("expected the detected format to return %v, but got: %v, %v", taints, detectedTaints, err)
+	}
+}
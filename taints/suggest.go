@@ -0,0 +1,75 @@
+package taints
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// suggest returns the candidate closest to the misspelled word, ignoring case, or an empty string
+// if none is close enough to be a likely typo. Ties go to the first candidate in sorted order.
+func suggest(word string, candidates []string) string {
+	if len(word) == 0 {
+		return ""
+	}
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best, bestDistance := "", len(word)/4+2
+	for _, candidate := range sorted {
+		if candidate == word {
+			continue
+		}
+		if d := editDistance(strings.ToLower(word), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// suggestEffect returns the accepted effect name closest to the unsupported effect.
+func (o *options) suggestEffect(effect v1.TaintEffect) string {
+	var candidates []string
+	for e := range o.profile.effects {
+		if o.allowedEffects == nil || o.allowedEffects.Has(e) {
+			candidates = append(candidates, string(e))
+		}
+	}
+	for name := range o.effectTranslation {
+		candidates = append(candidates, name)
+	}
+	return suggest(string(effect), candidates)
+}
+
+// suggestKey returns the known key closest to the unknown key of a removal.
+func (o *options) suggestKey(key string, added sets.Set[string]) string {
+	candidates := append(sets.List(o.knownKeys), sets.List(added)...)
+	for _, taint := range wellKnownTaints {
+		candidates = append(candidates, taint.Key)
+	}
+	return suggest(key, candidates)
+}
@@ -0,0 +1,81 @@
+package taints
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEffectSuggestions(t *testing.T) {
+	cases := []struct {
+		name               string
+		spec               string
+		opts               []Option
+		expectedSuggestion string
+	}{
+		{
+			name:               "misspelled effect",
+			spec:               "foo=abc:NoSchedulle",
+			expectedSuggestion: "NoSchedule",
+		},
+		{
+			name:               "wrong case",
+			spec:               "foo=abc:noexecute",
+			expectedSuggestion: "NoExecute",
+		},
+		{
+			name:               "removal",
+			spec:               "foo:PreferNoSchedul-",
+			expectedSuggestion: "PreferNoSchedule",
+		},
+		{
+			name:               "translated effect",
+			spec:               "foo=abc:EVCT",
+			opts:               []Option{WithEffectTranslation(testEffectTranslation)},
+			expectedSuggestion: "EVICT",
+		},
+		{
+			name: "effect outside of allowed effects",
+			spec: "foo=abc:NoExecut",
+			opts: []Option{WithAllowedEffects("NoSchedule")},
+		},
+		{
+			name: "unrelated effect",
+			spec: "foo=abc:Never",
+		},
+	}
+
+	for _, c := range cases {
+		_, _, err := ParseTaintsWithOptions([]string{c.spec}, c.opts...)
+		var specErr *SpecError
+		if !errors.As(err, &specErr) || !errors.Is(err, ErrInvalidEffect) {
+			t.Errorf("[%s] expected an invalid effect error, but got: %v", c.name, err)
+			continue
+		}
+		if specErr.Suggestion != c.expectedSuggestion {
+			t.Errorf("[%s] expected suggestion %q, but got: %q", c.name, c.expectedSuggestion, specErr.Suggestion)
+		}
+	}
+
+	_, _, err := ParseTaints([]string{"foo=abc:NoSchedulle"})
+	if expected := "invalid taint effect: NoSchedulle, unsupported taint effect, did you mean NoSchedule?"; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, but got: %v", expected, err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "abc", expected: 3},
+		{a: "NoSchedule", b: "NoSchedule", expected: 0},
+		{a: "NoSchedulle", b: "NoSchedule", expected: 1},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+
+	for _, c := range cases {
+		if d := editDistance(c.a, c.b); d != c.expected {
+			t.Errorf("expected distance %d between %q and %q, but got: %d", c.expected, c.a, c.b, d)
+		}
+	}
+}
@@ -18,49 +18,68 @@ package taints
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/sets"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// TaintOperation classifies what ReorganizeTaints does to a node's taints.
+type TaintOperation string
+
+const (
+	// OperationModified adds and removes taints, or overwrites existing ones.
+	OperationModified TaintOperation = "modified"
+	// OperationTainted only adds taints.
+	OperationTainted TaintOperation = "tainted"
+	// OperationUntainted only removes taints, or changes nothing.
+	OperationUntainted TaintOperation = "untainted"
+)
+
 // parseTaint parses a taint from a string, whose form must be either
 // '<key>=<value>:<effect>', '<key>:<effect>', or '<key>'.
-func parseTaint(st string) (v1.Taint, error) {
+func (o *options) parseTaint(st string) (v1.Taint, error) {
 	var taint v1.Taint
 
 	var key string
 	var value string
 	var effect v1.TaintEffect
 
-	parts := strings.Split(st, ":")
-	switch len(parts) {
+	// count the separators rather than splitting, which keeps the common case free of allocations
+	switch strings.Count(st, ":") {
+	case 0:
+		key = st
 	case 1:
-		key = parts[0]
-	case 2:
-		effect = v1.TaintEffect(parts[1])
-		if err := validateTaintEffect(effect); err != nil {
+		kv, effectName, _ := strings.Cut(st, ":")
+		effect = o.translateEffect(effectName)
+		if err := o.validateTaintEffect(effect); err != nil {
+			err.Spec = st
+			err.Offset = len(kv) + 1
 			return taint, err
 		}
 
-		partsKV := strings.Split(parts[0], "=")
-		if len(partsKV) > 2 {
-			return taint, fmt.Errorf("invalid taint spec: %v", st)
+		if strings.Count(kv, "=") > 1 {
+			return taint, &SpecError{Spec: st, Err: ErrInvalidSpec, Offset: secondIndex(kv, '=')}
 		}
-		key = partsKV[0]
-		if len(partsKV) == 2 {
-			value = partsKV[1]
+		var hasValue bool
+		key, value, hasValue = strings.Cut(kv, "=")
+		if hasValue {
 			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
-				return taint, fmt.Errorf("invalid taint spec: %v, %s", st, strings.Join(errs, "; "))
+				offset := len(key) + 1 + nameErrorOffset(value, validation.LabelValueMaxLength)
+				return taint, &SpecError{Spec: st, Field: "value", Value: value, Err: ErrInvalidValue, Detail: strings.Join(errs, "; "), Offset: offset}
 			}
 		}
 	default:
-		return taint, fmt.Errorf("invalid taint spec: %v", st)
+		return taint, &SpecError{Spec: st, Err: ErrInvalidSpec, Offset: secondIndex(st, ':')}
 	}
 
-	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
-		return taint, fmt.Errorf("invalid taint spec: %v, %s", st, strings.Join(errs, "; "))
+	if !isQualifiedName(key) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return taint, &SpecError{Spec: st, Field: "key", Value: key, Err: ErrInvalidKey, Detail: strings.Join(errs, "; "), Offset: keyErrorOffset(key)}
+		}
 	}
 
 	taint.Key = key
@@ -70,9 +89,17 @@ func parseTaint(st string) (v1.Taint, error) {
 	return taint, nil
 }
 
-func validateTaintEffect(effect v1.TaintEffect) error {
-	if effect != v1.TaintEffectNoSchedule && effect != v1.TaintEffectPreferNoSchedule && effect != v1.TaintEffectNoExecute {
-		return fmt.Errorf("invalid taint effect: %v, unsupported taint effect", effect)
+func (o *options) validateTaintEffect(effect v1.TaintEffect) *SpecError {
+	// allowed effects are checked first, so that WithUnknownEffects does not bypass them
+	if o.allowedEffects != nil && (!o.allowedEffects.Has(effect) || !o.profile.effects.Has(effect)) {
+		return &SpecError{Field: "effect", Value: string(effect), Err: ErrInvalidEffect, Suggestion: o.suggestEffect(effect)}
+	}
+	if !o.profile.effects.Has(effect) {
+		if o.allowUnknownEffects && len(effect) > 0 {
+			o.warnf("unknown taint effect: %v, passing it through unchanged", effect)
+			return nil
+		}
+		return &SpecError{Field: "effect", Value: string(effect), Err: ErrInvalidEffect, Suggestion: o.suggestEffect(effect)}
 	}
 
 	return nil
@@ -80,38 +107,391 @@ func validateTaintEffect(effect v1.TaintEffect) error {
 
 // ParseTaints takes a spec which is an array and creates slices for new taints to be added, taints to be deleted.
 // It also validates the spec. For example, the form `<key>` may be used to remove a taint, but not to add one.
+// Both slices are guaranteed to hold the taints in the order of their specs.
 func ParseTaints(spec []string) ([]v1.Taint, []v1.Taint, error) {
-	var taints, taintsToRemove []v1.Taint
-	uniqueTaints := map[v1.TaintEffect]sets.String{}
+	return ParseTaintsWithOptions(spec)
+}
+
+// ParseTaintsWithOptions behaves like ParseTaints, with its behavior adjusted by the given options.
+func ParseTaintsWithOptions(spec []string, opts ...Option) ([]v1.Taint, []v1.Taint, error) {
+	result, err := Parse(spec, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Adds, result.Removals, nil
+}
+
+func (o *options) parseTaints(spec []string) (*parsedSpecs, error) {
+	parsed := o.parseSpecs(spec, false)
+	if len(parsed.errs) > 0 {
+		o.localize(parsed.errs[0].Err)
+		return nil, parsed.errs[0].Err
+	}
+	if len(parsed.duplicates) > 0 {
+		err := &DuplicateTaintError{Duplicates: parsed.duplicates, messages: o.messages}
+		if o.duplicates == DuplicatesError {
+			return nil, err
+		}
+		o.warn(err.Error())
+	}
+	o.warnUnknownRemovals(parsed.taints, parsed.taintsToRemove)
+	return parsed, nil
+}
+
+// parsedSpecs is the outcome of parsing a list of specs.
+type parsedSpecs struct {
+	// taints and taintsToRemove are those of the valid entries. Taints added again by a later
+	// entry are only kept once.
+	taints         []v1.Taint
+	taintsToRemove []TaintRemoval
+	// taintIndices and removalIndices are the indices of the entries of taints and
+	// taintsToRemove.
+	taintIndices   []int
+	removalIndices []int
+	// errs are the errors of the invalid entries, other than duplicates, in order.
+	errs []*ParseError
+	// duplicates are the taints added by several entries, with the indices of the entries.
+	duplicates []DuplicateTaint
+}
+
+// parseSpecs parses the entries of a spec list, stopping at the first invalid entry unless all
+// is set.
+func (o *options) parseSpecs(spec []string, all bool) *parsedSpecs {
+	parsed := &parsedSpecs{}
+	uniqueTaints := map[v1.Taint][]int{}
+	var duplicates []v1.Taint
+
+	for i, entry := range spec {
+		taints, taintsToRemove, err := o.parseEntry(entry)
+		if err != nil {
+			parsed.errs = append(parsed.errs, &ParseError{Index: i, Spec: entry, Err: err})
+			if !all {
+				break
+			}
+			continue
+		}
+		for _, newTaint := range taints {
+			// validate if taint is unique by <key, effect>, collecting every duplicate
+			id := v1.Taint{Key: newTaint.Key, Effect: newTaint.Effect}
+			indices := uniqueTaints[id]
+			if len(indices) == 1 {
+				duplicates = append(duplicates, id)
+			}
+			uniqueTaints[id] = append(indices, i)
+			switch {
+			case len(indices) == 0:
+				parsed.taints = append(parsed.taints, newTaint)
+				parsed.taintIndices = append(parsed.taintIndices, i)
+			case o.duplicates == DuplicatesKeepLast:
+				pos := indexOfTaint(parsed.taints, &newTaint)
+				parsed.taints[pos] = newTaint
+				parsed.taintIndices[pos] = i
+			}
+		}
+		parsed.taintsToRemove = append(parsed.taintsToRemove, taintsToRemove...)
+		for range taintsToRemove {
+			parsed.removalIndices = append(parsed.removalIndices, i)
+		}
+	}
+	for _, id := range duplicates {
+		parsed.duplicates = append(parsed.duplicates, DuplicateTaint{Key: id.Key, Effect: id.Effect, Indices: uniqueTaints[id]})
+	}
+	return parsed
+}
 
-	for _, taintSpec := range spec {
+// parseEntry parses an entry of a spec list, which adds or removes a taint, or references a
+// bundle.
+func (o *options) parseEntry(entry string) ([]v1.Taint, []TaintRemoval, error) {
+	if o.trimSpace {
+		entry = strings.TrimSpace(entry)
+	}
+	if len(strings.TrimSpace(entry)) == 0 {
+		if o.skipEmpty {
+			return nil, nil, nil
+		}
+		return nil, nil, &SpecError{Spec: entry, Err: ErrEmptySpec}
+	}
+	expanded, err := o.expandBundle(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var taints []v1.Taint
+	var taintsToRemove []TaintRemoval
+	for _, taintSpec := range expanded {
 		if strings.HasSuffix(taintSpec, "-") {
-			taintToRemove, err := parseTaint(strings.TrimSuffix(taintSpec, "-"))
-			if err != nil {
-				return nil, nil, err
+			removalSpec := strings.TrimSuffix(taintSpec, "-")
+			// reject a missing key before parsing, which would blame the effect or the key format
+			if len(removalSpec) == 0 || strings.IndexAny(removalSpec, "=:") == 0 {
+				return nil, nil, &SpecError{Spec: removalSpec, Field: "key", Err: ErrEmptyRemovalKey}
 			}
-			taintsToRemove = append(taintsToRemove, v1.Taint{Key: taintToRemove.Key, Effect: taintToRemove.Effect})
-		} else {
-			newTaint, err := parseTaint(taintSpec)
+			taintToRemove, err := o.parseTaint(removalSpec)
 			if err != nil {
 				return nil, nil, err
 			}
-			// validate that the taint has an effect, which is required to add the taint
-			if len(newTaint.Effect) == 0 {
-				return nil, nil, fmt.Errorf("invalid taint spec: %v", taintSpec)
+			if o.strict && strings.Contains(strings.Split(removalSpec, ":")[0], "=") {
+				return nil, nil, &SpecError{Spec: removalSpec, Field: "value", Value: taintToRemove.Value, Err: ErrInvalidSpec, Detail: "write " + FormatRemoval(taintToRemove) + " instead", Offset: strings.Index(removalSpec, "=")}
 			}
-			// validate if taint is unique by <key, effect>
-			if len(uniqueTaints[newTaint.Effect]) > 0 && uniqueTaints[newTaint.Effect].Has(newTaint.Key) {
-				return nil, nil, fmt.Errorf("duplicated taints with the same key and effect: %v", newTaint)
+			removal := TaintRemoval{Key: taintToRemove.Key, Effect: taintToRemove.Effect, Value: taintToRemove.Value, MatchMode: MatchKeyAndEffect}
+			if len(removal.Effect) == 0 {
+				removal.MatchMode = MatchKey
 			}
-			// add taint to existingTaints for uniqueness check
-			if len(uniqueTaints[newTaint.Effect]) == 0 {
-				uniqueTaints[newTaint.Effect] = sets.String{}
+			taintsToRemove = append(taintsToRemove, removal)
+			continue
+		}
+		newTaint, err := o.parseTaint(taintSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		if o.strict && strings.HasSuffix(strings.Split(taintSpec, ":")[0], "=") {
+			return nil, nil, &SpecError{Spec: taintSpec, Field: "value", Err: ErrInvalidSpec, Detail: "write " + FormatTaint(newTaint) + " instead", Offset: strings.Index(taintSpec, "=")}
+		}
+		// validate that the taint has an effect, which is required to add the taint
+		if len(newTaint.Effect) == 0 {
+			return nil, nil, &SpecError{Spec: taintSpec, Field: "effect", Err: ErrMissingEffect, Offset: len(taintSpec)}
+		}
+		if err := checkPolicies(newTaint, o.policies); err != nil {
+			return nil, nil, err
+		}
+		taints = append(taints, newTaint)
+	}
+	return taints, taintsToRemove, nil
+}
+
+// allErrors returns the errors of the invalid entries, including one for every entry adding a taint
+// added by an earlier entry, ordered by index.
+func (p *parsedSpecs) allErrors(spec []string) ParseErrors {
+	errs := append(ParseErrors(nil), p.errs...)
+	for _, duplicate := range p.duplicates {
+		for _, i := range duplicate.Indices[1:] {
+			errs = append(errs, &ParseError{Index: i, Spec: spec[i], Err: &DuplicateTaintError{Duplicates: []DuplicateTaint{duplicate}}})
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Index < errs[j].Index
+	})
+	return errs
+}
+
+// ParseTaintsAll behaves like ParseTaints, except that it validates every entry of the spec
+// rather than stopping at the first invalid one. Its error is a ParseErrors listing every invalid
+// entry with its index.
+func ParseTaintsAll(spec []string) ([]v1.Taint, []v1.Taint, error) {
+	o, err := newOptions(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed := o.parseSpecs(spec, true)
+	if errs := parsed.allErrors(spec); len(errs) > 0 {
+		return nil, nil, errs
+	}
+	return parsed.taints, removalTaints(parsed.taintsToRemove), nil
+}
+
+// ParseTaintsLenient behaves like ParseTaintsAll, except that it also returns the taints of the
+// valid entries. Of the entries adding the same taint, only the first one is valid.
+func ParseTaintsLenient(spec []string) ([]v1.Taint, []v1.Taint, ParseErrors) {
+	o, _ := newOptions(nil)
+	parsed := o.parseSpecs(spec, true)
+	return parsed.taints, removalTaints(parsed.taintsToRemove), parsed.allErrors(spec)
+}
+
+// ParseTaintsFromString behaves like ParseTaints for a comma-separated list of specs, the form of
+// the --register-with-taints kubelet flag, such as 'foo=abc:NoSchedule,bar:NoExecute,baz-'. Each
+// entry keeps its own '-' suffix, so removals may appear anywhere in the list. An empty string
+// holds no specs.
+func ParseTaintsFromString(spec string) ([]v1.Taint, []v1.Taint, error) {
+	return ParseTaints(splitSpecList(spec))
+}
+
+// splitSpecList splits a comma-separated list of specs.
+func splitSpecList(spec string) []string {
+	if len(spec) == 0 {
+		return nil
+	}
+	return strings.Split(spec, ",")
+}
+
+// AddOrUpdateTaint tries to add a taint to annotations list. Returns a new copy of updated Node and true if something was updated
+// false otherwise. It is an error for a policy registered with WithPolicy to forbid the taint, and hooks registered
+// with WithHooks are notified of the update if they implement ApplyHooks.
+func AddOrUpdateTaint(node *v1.Node, taint *v1.Taint, opts ...Option) (*v1.Node, bool, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := checkPolicies(*taint, o.policies); err != nil {
+		return nil, false, err
+	}
+	newNode := node.DeepCopy()
+	nodeTaints := newNode.Spec.Taints
+
+	var newTaints []v1.Taint
+	updated := false
+	for i := range nodeTaints {
+		if taint.MatchTaint(&nodeTaints[i]) {
+			if apiequality.Semantic.DeepEqual(*taint, nodeTaints[i]) {
+				return newNode, false, nil
 			}
-			uniqueTaints[newTaint.Effect].Insert(newTaint.Key)
+			newTaints = append(newTaints, *taint)
+			updated = true
+			continue
+		}
+
+		newTaints = append(newTaints, nodeTaints[i])
+	}
+
+	if !updated {
+		newTaints = append(newTaints, *taint)
+	}
+
+	newNode.Spec.Taints = newTaints
+	o.applyHooks().OnApply(node.Name, []v1.Taint{*taint}, nil)
+	return newNode, true, nil
+}
 
-			taints = append(taints, newTaint)
+// DeleteTaintsByKey removes all the taints that have the same key to given taintKey
+func DeleteTaintsByKey(taints []v1.Taint, taintKey string) ([]v1.Taint, bool) {
+	newTaints := []v1.Taint{}
+	deleted := false
+	for i := range taints {
+		if taintKey == taints[i].Key {
+			deleted = true
+			continue
 		}
+		newTaints = append(newTaints, taints[i])
 	}
-	return taints, taintsToRemove, nil
+	return newTaints, deleted
+}
+
+// DeleteTaint removes all the taints that match the given taintToDelete, as returned by ParseTaints
+// in the taints to be removed: taints with the same key and effect, or with the same key if
+// taintToDelete has no effect.
+func DeleteTaint(taints []v1.Taint, taintToDelete *v1.Taint) ([]v1.Taint, bool) {
+	newTaints := []v1.Taint{}
+	deleted := false
+	for i := range taints {
+		if removalMatches(taintToDelete, &taints[i]) {
+			deleted = true
+			continue
+		}
+		newTaints = append(newTaints, taints[i])
+	}
+	return newTaints, deleted
+}
+
+// RemoveTaint tries to remove a taint from annotations list. Returns a new copy of updated Node and true if something was updated
+// false otherwise.
+func RemoveTaint(node *v1.Node, taint *v1.Taint) (*v1.Node, bool, error) {
+	newNode := node.DeepCopy()
+	nodeTaints := newNode.Spec.Taints
+	if len(nodeTaints) == 0 {
+		return newNode, false, nil
+	}
+
+	newTaints, deleted := DeleteTaint(nodeTaints, taint)
+	if !deleted {
+		return newNode, false, nil
+	}
+	newNode.Spec.Taints = newTaints
+	return newNode, true, nil
+}
+
+// CheckIfTaintsAlreadyExists checks if the node already has taints that we want to add and returns a string with taint keys.
+func CheckIfTaintsAlreadyExists(oldTaints []v1.Taint, taints []v1.Taint) string {
+	var existingTaintList = make([]string, 0)
+	for _, taint := range taints {
+		for _, oldTaint := range oldTaints {
+			if taint.Key == oldTaint.Key && taint.Effect == oldTaint.Effect {
+				existingTaintList = append(existingTaintList, taint.Key)
+			}
+		}
+	}
+	return strings.Join(existingTaintList, ",")
+}
+
+// TaintExists checks if the given taint exists in list of taints. Returns true if exists false otherwise.
+func TaintExists(taints []v1.Taint, taintToFind *v1.Taint) bool {
+	for _, taint := range taints {
+		if taint.MatchTaint(taintToFind) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReorganizeTaints returns the updated set of taints, taking into account old taints that were not updated,
+// old taints that were updated, old taints that were deleted, and new taints.
+// Unless overwrite is set, it is an error for the node to already have taints to add with the same key and effect.
+// It is also an error for a policy registered with WithPolicy to forbid a taint to add. Hooks registered with
+// WithHooks are notified of the conflicts and of the applied taints if they implement ApplyHooks.
+func ReorganizeTaints(node *v1.Node, overwrite bool, taintsToAdd []v1.Taint, taintsToRemove []v1.Taint, opts ...Option) (TaintOperation, []v1.Taint, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, taint := range taintsToAdd {
+		if err := checkPolicies(taint, o.policies); err != nil {
+			return "", nil, err
+		}
+	}
+	if !overwrite {
+		if exists := CheckIfTaintsAlreadyExists(node.Spec.Taints, taintsToAdd); len(exists) != 0 {
+			var conflicts []v1.Taint
+			for _, taint := range taintsToAdd {
+				if TaintExists(node.Spec.Taints, &taint) {
+					conflicts = append(conflicts, taint)
+				}
+			}
+			o.applyHooks().OnConflict(node.Name, conflicts)
+			return "", nil, fmt.Errorf("node %s already has %v taint(s) with same effect(s) and overwrite is false", node.Name, exists)
+		}
+	}
+
+	newTaints := append([]v1.Taint{}, taintsToAdd...)
+	oldTaints := node.Spec.Taints
+	// add taints that already existing but not updated to newTaints
+	added := addTaints(oldTaints, &newTaints)
+	allErrs, deleted := deleteTaints(taintsToRemove, &newTaints)
+	o.applyHooks().OnApply(node.Name, taintsToAdd, taintsToRemove)
+	if (added && deleted) || overwrite {
+		return OperationModified, newTaints, utilerrors.NewAggregate(allErrs)
+	} else if added {
+		return OperationTainted, newTaints, utilerrors.NewAggregate(allErrs)
+	}
+	return OperationUntainted, newTaints, utilerrors.NewAggregate(allErrs)
+}
+
+// deleteTaints deletes the given taints from the node's taintlist.
+func deleteTaints(taintsToRemove []v1.Taint, newTaints *[]v1.Taint) ([]error, bool) {
+	allErrs := []error{}
+	var removed bool
+	for _, taintToRemove := range taintsToRemove {
+		if len(taintToRemove.Effect) > 0 {
+			*newTaints, removed = DeleteTaint(*newTaints, &taintToRemove)
+		} else {
+			*newTaints, removed = DeleteTaintsByKey(*newTaints, taintToRemove.Key)
+		}
+		if !removed {
+			allErrs = append(allErrs, fmt.Errorf("taint %q not found", taintToRemove.ToString()))
+		}
+	}
+	return allErrs, removed
+}
+
+// addTaints adds the newTaints list to existing ones and updates the newTaints List.
+func addTaints(oldTaints []v1.Taint, newTaints *[]v1.Taint) bool {
+	for _, oldTaint := range oldTaints {
+		existsInNew := false
+		for _, taint := range *newTaints {
+			if taint.MatchTaint(&oldTaint) {
+				existsInNew = true
+				break
+			}
+		}
+		if !existsInNew {
+			*newTaints = append(*newTaints, oldTaint)
+		}
+	}
+	return len(oldTaints) != len(*newTaints)
 }
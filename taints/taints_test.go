@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestParseTaints(t *testing.T) {
@@ -234,3 +235,625 @@ func TestParseTaints(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTaintsSortedOutput(t *testing.T) {
+	spec := []string{"foo=abc:NoSchedule", "bar:NoExecute", "foo:NoExecute", "qux-", "baz:NoSchedule-"}
+
+	taints, taintsToRemove, err := ParseTaintsWithOptions(spec, WithSortedOutput())
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expectedTaints := []v1.Taint{
+		{Key: "bar", Effect: v1.TaintEffectNoExecute},
+		{Key: "foo", Effect: v1.TaintEffectNoExecute},
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+	}
+	if !reflect.DeepEqual(expectedTaints, taints) {
+		t.Errorf("expected taints %v, but got: %v", expectedTaints, taints)
+	}
+	expectedTaintsToRemove := []v1.Taint{
+		{Key: "baz", Effect: v1.TaintEffectNoSchedule},
+		{Key: "qux"},
+	}
+	if !reflect.DeepEqual(expectedTaintsToRemove, taintsToRemove) {
+		t.Errorf("expected taints to be removed %v, but got: %v", expectedTaintsToRemove, taintsToRemove)
+	}
+}
+
+func TestParseTaintsFromString(t *testing.T) {
+	cases := []struct {
+		name                   string
+		spec                   string
+		expectedTaints         []v1.Taint
+		expectedTaintsToRemove []v1.Taint
+		expectedErr            bool
+	}{
+		{
+			name: "empty string",
+			spec: "",
+		},
+		{
+			name: "kubelet flag",
+			spec: "foo=abc:NoSchedule,bar:NoExecute",
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:           "removals",
+			spec:           "qux-,foo=abc:NoSchedule,bar:NoExecute-",
+			expectedTaints: []v1.Taint{{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaintsToRemove: []v1.Taint{
+				{Key: "qux"},
+				{Key: "bar", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:        "trailing comma",
+			spec:        "foo=abc:NoSchedule,",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid spec",
+			spec:        "foo=abc:NoSchedule,bar",
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		taints, taintsToRemove, err := ParseTaintsFromString(c.spec)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %q, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %q, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if !reflect.DeepEqual(c.expectedTaintsToRemove, taintsToRemove) {
+			t.Errorf("[%s] expected taints to be removed %v, but got: %v", c.name, c.expectedTaintsToRemove, taintsToRemove)
+		}
+	}
+}
+
+func TestAddOrUpdateTaint(t *testing.T) {
+	taint := v1.Taint{
+		Key:    "foo_1",
+		Value:  "oldValue",
+		Effect: v1.TaintEffectNoSchedule,
+	}
+
+	taintNew := v1.Taint{
+		Key:    "foo_2",
+		Value:  "newValue",
+		Effect: v1.TaintEffectNoSchedule,
+	}
+
+	taintUpdateValue := taint
+	taintUpdateValue.Value = "newValue"
+
+	checkResult := func(testCaseName string, newNode *v1.Node, expectedTaint v1.Taint, result, expectedResult bool, err error) {
+		if err != nil {
+			t.Errorf("[%s] should not raise error but got %v", testCaseName, err)
+		}
+		if result != expectedResult {
+			t.Errorf("[%s] should return %t, but got: %t", testCaseName, expectedResult, result)
+		}
+		if i := indexOfTaint(newNode.Spec.Taints, &expectedTaint); i < 0 || newNode.Spec.Taints[i] != expectedTaint {
+			t.Errorf("[%s] expected taint %v to exist in newNode.Spec.Taints: %v", testCaseName, expectedTaint, newNode.Spec.Taints)
+		}
+	}
+
+	cases := []struct {
+		name           string
+		node           *v1.Node
+		taint          *v1.Taint
+		expectedResult bool
+	}{
+		{
+			name:           "add a new taint",
+			node:           &v1.Node{},
+			taint:          &taint,
+			expectedResult: true,
+		},
+		{
+			name:           "add a unique taint",
+			node:           &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{taint}}},
+			taint:          &taintNew,
+			expectedResult: true,
+		},
+		{
+			name:           "add duplicate taint",
+			node:           &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{taint}}},
+			taint:          &taint,
+			expectedResult: false,
+		},
+		{
+			name:           "update taint value",
+			node:           &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{taint}}},
+			taint:          &taintUpdateValue,
+			expectedResult: true,
+		},
+	}
+	for _, c := range cases {
+		newNode, result, err := AddOrUpdateTaint(c.node, c.taint)
+		checkResult(c.name, newNode, *c.taint, result, c.expectedResult, err)
+	}
+}
+
+func TestDeleteTaintsByKey(t *testing.T) {
+	cases := []struct {
+		name           string
+		taints         []v1.Taint
+		taintKey       string
+		expectedTaints []v1.Taint
+		expectedResult bool
+	}{
+		{
+			name: "delete taint with different name by key",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			taintKey: "foo_1",
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "delete taint by key",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoExecute,
+				},
+				{
+					Key:    "bar",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			taintKey: "foo",
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "bar",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "delete taint by key from empty taint array",
+			taints:         []v1.Taint{},
+			taintKey:       "foo",
+			expectedTaints: []v1.Taint{},
+			expectedResult: false,
+		},
+	}
+
+	for _, c := range cases {
+		taints, result := DeleteTaintsByKey(c.taints, c.taintKey)
+		if result != c.expectedResult {
+			t.Errorf("[%s] should return %t, but got: %t", c.name, c.expectedResult, result)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] the result taints should be %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+	}
+}
+
+func TestDeleteTaint(t *testing.T) {
+	cases := []struct {
+		name           string
+		taints         []v1.Taint
+		taintToDelete  *v1.Taint
+		expectedTaints []v1.Taint
+		expectedResult bool
+	}{
+		{
+			name: "delete taint with different name",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			taintToDelete: &v1.Taint{Key: "foo_1", Effect: v1.TaintEffectNoSchedule},
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "delete taint with different effect",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			taintToDelete: &v1.Taint{Key: "foo", Effect: v1.TaintEffectNoExecute},
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "delete taint successfully",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			taintToDelete:  &v1.Taint{Key: "foo", Effect: v1.TaintEffectNoSchedule},
+			expectedTaints: []v1.Taint{},
+			expectedResult: true,
+		},
+		{
+			name: "delete every effect of a key",
+			taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "bar",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoExecute,
+				},
+			},
+			taintToDelete: &v1.Taint{Key: "foo"},
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "bar",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "delete taint from empty taint array",
+			taints:         []v1.Taint{},
+			taintToDelete:  &v1.Taint{Key: "foo", Effect: v1.TaintEffectNoSchedule},
+			expectedTaints: []v1.Taint{},
+			expectedResult: false,
+		},
+	}
+
+	for _, c := range cases {
+		taints, result := DeleteTaint(c.taints, c.taintToDelete)
+		if result != c.expectedResult {
+			t.Errorf("[%s] should return %t, but got: %t", c.name, c.expectedResult, result)
+		}
+		if !reflect.DeepEqual(taints, c.expectedTaints) {
+			t.Errorf("[%s] the result taints should be %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+	}
+}
+
+func TestRemoveTaint(t *testing.T) {
+	cases := []struct {
+		name           string
+		node           *v1.Node
+		taintToRemove  *v1.Taint
+		expectedTaints []v1.Taint
+		expectedResult bool
+	}{
+		{
+			name: "remove taint unsuccessfully",
+			node: &v1.Node{
+				Spec: v1.NodeSpec{
+					Taints: []v1.Taint{
+						{
+							Key:    "foo",
+							Effect: v1.TaintEffectNoSchedule,
+						},
+					},
+				},
+			},
+			taintToRemove: &v1.Taint{
+				Key:    "foo_1",
+				Effect: v1.TaintEffectNoSchedule,
+			},
+			expectedTaints: []v1.Taint{
+				{
+					Key:    "foo",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "remove taint successfully",
+			node: &v1.Node{
+				Spec: v1.NodeSpec{
+					Taints: []v1.Taint{
+						{
+							Key:    "foo",
+							Effect: v1.TaintEffectNoSchedule,
+						},
+					},
+				},
+			},
+			taintToRemove: &v1.Taint{
+				Key:    "foo",
+				Effect: v1.TaintEffectNoSchedule,
+			},
+			expectedTaints: []v1.Taint{},
+			expectedResult: true,
+		},
+		{
+			name: "remove taint from node with no taint",
+			node: &v1.Node{
+				Spec: v1.NodeSpec{
+					Taints: []v1.Taint{},
+				},
+			},
+			taintToRemove: &v1.Taint{
+				Key:    "foo",
+				Effect: v1.TaintEffectNoSchedule,
+			},
+			expectedTaints: []v1.Taint{},
+			expectedResult: false,
+		},
+	}
+
+	for _, c := range cases {
+		newNode, result, err := RemoveTaint(c.node, c.taintToRemove)
+		if err != nil {
+			t.Errorf("[%s] should not raise error but got: %v", c.name, err)
+		}
+		if result != c.expectedResult {
+			t.Errorf("[%s] should return %t, but got: %t", c.name, c.expectedResult, result)
+		}
+		if !reflect.DeepEqual(newNode.Spec.Taints, c.expectedTaints) {
+			t.Errorf("[%s] the new node object should have taints %v, but got: %v", c.name, c.expectedTaints, newNode.Spec.Taints)
+		}
+	}
+}
+
+func TestCheckIfTaintsAlreadyExists(t *testing.T) {
+	oldTaints := []v1.Taint{
+		{
+			Key:    "foo_1",
+			Value:  "bar",
+			Effect: v1.TaintEffectNoSchedule,
+		},
+		{
+			Key:    "foo_2",
+			Value:  "bar",
+			Effect: v1.TaintEffectNoSchedule,
+		},
+		{
+			Key:    "foo_3",
+			Value:  "bar",
+			Effect: v1.TaintEffectNoSchedule,
+		},
+	}
+
+	cases := []struct {
+		name           string
+		taintsToCheck  []v1.Taint
+		expectedResult string
+	}{
+		{
+			name:           "empty array",
+			taintsToCheck:  []v1.Taint{},
+			expectedResult: "",
+		},
+		{
+			name: "no match",
+			taintsToCheck: []v1.Taint{
+				{
+					Key:    "foo_1",
+					Effect: v1.TaintEffectNoExecute,
+				},
+			},
+			expectedResult: "",
+		},
+		{
+			name: "match one taint",
+			taintsToCheck: []v1.Taint{
+				{
+					Key:    "foo_2",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: "foo_2",
+		},
+		{
+			name: "match two taints",
+			taintsToCheck: []v1.Taint{
+				{
+					Key:    "foo_2",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "foo_3",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+			expectedResult: "foo_2,foo_3",
+		},
+	}
+
+	for _, c := range cases {
+		result := CheckIfTaintsAlreadyExists(oldTaints, c.taintsToCheck)
+		if result != c.expectedResult {
+			t.Errorf("[%s] should return '%s', but got: '%s'", c.name, c.expectedResult, result)
+		}
+	}
+}
+
+func TestTaintExists(t *testing.T) {
+	testingTaints := []v1.Taint{
+		{
+			Key:    "foo_1",
+			Value:  "bar_1",
+			Effect: v1.TaintEffectNoExecute,
+		},
+		{
+			Key:    "foo_2",
+			Value:  "bar_2",
+			Effect: v1.TaintEffectNoSchedule,
+		},
+	}
+
+	cases := []struct {
+		name           string
+		taintToFind    *v1.Taint
+		expectedResult bool
+	}{
+		{
+			name:           "taint exists",
+			taintToFind:    &v1.Taint{Key: "foo_1", Value: "bar_1", Effect: v1.TaintEffectNoExecute},
+			expectedResult: true,
+		},
+		{
+			name:           "different key",
+			taintToFind:    &v1.Taint{Key: "no_taint", Value: "bar_1", Effect: v1.TaintEffectNoExecute},
+			expectedResult: false,
+		},
+		{
+			name:           "different effect",
+			taintToFind:    &v1.Taint{Key: "foo_1", Value: "bar_1", Effect: v1.TaintEffectNoSchedule},
+			expectedResult: false,
+		},
+	}
+
+	for _, c := range cases {
+		result := TaintExists(testingTaints, c.taintToFind)
+
+		if result != c.expectedResult {
+			t.Errorf("[%s] unexpected results: %v", c.name, result)
+			continue
+		}
+	}
+}
+
+func TestReorganizeTaints(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{
+				{
+					Key:    "foo",
+					Value:  "bar",
+					Effect: v1.TaintEffectNoSchedule,
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name              string
+		overwrite         bool
+		taintsToAdd       []v1.Taint
+		taintsToDelete    []v1.Taint
+		expectedTaints    []v1.Taint
+		expectedOperation TaintOperation
+		expectedErr       bool
+	}{
+		{
+			name:              "no changes with overwrite is true",
+			overwrite:         true,
+			taintsToAdd:       []v1.Taint{},
+			taintsToDelete:    []v1.Taint{},
+			expectedTaints:    node.Spec.Taints,
+			expectedOperation: OperationModified,
+		},
+		{
+			name:              "no changes with overwrite is false",
+			overwrite:         false,
+			taintsToAdd:       []v1.Taint{},
+			taintsToDelete:    []v1.Taint{},
+			expectedTaints:    node.Spec.Taints,
+			expectedOperation: OperationUntainted,
+		},
+		{
+			name:              "add new taint",
+			overwrite:         false,
+			taintsToAdd:       []v1.Taint{{Key: "foo_1", Effect: v1.TaintEffectNoExecute}},
+			taintsToDelete:    []v1.Taint{},
+			expectedTaints:    append([]v1.Taint{{Key: "foo_1", Effect: v1.TaintEffectNoExecute}}, node.Spec.Taints...),
+			expectedOperation: OperationTainted,
+		},
+		{
+			name:              "delete taint with effect",
+			overwrite:         false,
+			taintsToAdd:       []v1.Taint{},
+			taintsToDelete:    []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaints:    []v1.Taint{},
+			expectedOperation: OperationUntainted,
+		},
+		{
+			name:              "delete taint with no effect",
+			overwrite:         false,
+			taintsToAdd:       []v1.Taint{},
+			taintsToDelete:    []v1.Taint{{Key: "foo"}},
+			expectedTaints:    []v1.Taint{},
+			expectedOperation: OperationUntainted,
+		},
+		{
+			name:              "delete non-exist taint",
+			overwrite:         false,
+			taintsToAdd:       []v1.Taint{},
+			taintsToDelete:    []v1.Taint{{Key: "foo_1", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaints:    node.Spec.Taints,
+			expectedOperation: OperationUntainted,
+			expectedErr:       true,
+		},
+		{
+			name:              "update taint with overwrite",
+			overwrite:         true,
+			taintsToAdd:       []v1.Taint{{Key: "foo", Value: "baz", Effect: v1.TaintEffectNoSchedule}},
+			taintsToDelete:    []v1.Taint{},
+			expectedTaints:    []v1.Taint{{Key: "foo", Value: "baz", Effect: v1.TaintEffectNoSchedule}},
+			expectedOperation: OperationModified,
+		},
+		{
+			name:           "update taint without overwrite",
+			overwrite:      false,
+			taintsToAdd:    []v1.Taint{{Key: "foo", Value: "baz", Effect: v1.TaintEffectNoSchedule}},
+			taintsToDelete: []v1.Taint{},
+			expectedErr:    true,
+		},
+	}
+
+	for _, c := range cases {
+		operation, taints, err := ReorganizeTaints(node, c.overwrite, c.taintsToAdd, c.taintsToDelete)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+		if c.expectedOperation != operation {
+			t.Errorf("[%s] expected operation %s, but got: %s", c.name, c.expectedOperation, operation)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package taintstest provides utilities for testing code built on the taints package.
+package taintstest
+
+import (
+	"math/rand"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+var (
+	keys    = []string{"example.com/a", "example.com/b", "example.com/c", "dedicated", "spot"}
+	values  = []string{"", "x", "y", "gpu"}
+	effects = []v1.TaintEffect{v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute}
+)
+
+// Generator produces random, valid taints and specs. Keys and values are drawn from small pools
+// so that generated taints collide often, which is what exercises merge and diff logic. A
+// Generator is reproducible: the same seed always yields the same sequence.
+type Generator struct {
+	rand *rand.Rand
+}
+
+// NewGenerator returns a Generator seeded with seed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Taint returns a random taint.
+func (g *Generator) Taint() v1.Taint {
+	return v1.Taint{
+		Key:    keys[g.rand.Intn(len(keys))],
+		Value:  values[g.rand.Intn(len(values))],
+		Effect: effects[g.rand.Intn(len(effects))],
+	}
+}
+
+// Taints returns up to n random taints that are unique by key and effect, as on a node.
+func (g *Generator) Taints(n int) []v1.Taint {
+	var taints []v1.Taint
+	seen := map[v1.Taint]bool{}
+	for i := 0; i < n; i++ {
+		taint := g.Taint()
+		id := v1.Taint{Key: taint.Key, Effect: taint.Effect}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		taints = append(taints, taint)
+	}
+	return taints
+}
+
+// Spec returns up to n random specs that ParseTaints accepts, mixing adds, removals by key and
+// effect, and removals by key.
+func (g *Generator) Spec(n int) []string {
+	var spec []string
+	for _, taint := range g.Taints(n) {
+		switch g.rand.Intn(4) {
+		case 0:
+			spec = append(spec, taint.Key+":"+string(taint.Effect)+"-")
+		case 1:
+			spec = append(spec, taint.Key+"-")
+		default:
+			spec = append(spec, taint.ToString())
+		}
+	}
+	return spec
+}
@@ -0,0 +1,60 @@
+package taintstest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emre-aydin/kube-taint-parser/taints"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGeneratorIsReproducible(t *testing.T) {
+	a, b := NewGenerator(42), NewGenerator(42)
+	for i := 0; i < 100; i++ {
+		if specA, specB := a.Spec(8), b.Spec(8); !reflect.DeepEqual(specA, specB) {
+			t.Fatalf("expected generators with the same seed to agree, but got %v and %v", specA, specB)
+		}
+	}
+}
+
+func TestGeneratedSpecsParse(t *testing.T) {
+	g := NewGenerator(1)
+	for i := 0; i < 1000; i++ {
+		spec := g.Spec(8)
+		if _, _, err := taints.ParseTaints(spec); err != nil {
+			t.Fatalf("expected generated spec %v to parse, but got: %v", spec, err)
+		}
+	}
+}
+
+func TestDiffConverges(t *testing.T) {
+	g := NewGenerator(2)
+	for i := 0; i < 1000; i++ {
+		current, desired := g.Taints(6), g.Taints(6)
+		diff := taints.DiffTaints(current, desired)
+		applied := diff.Desired()
+		if !taints.DiffTaints(applied, desired).IsEmpty() {
+			t.Fatalf("expected applying the diff of %v to reach %v, but got: %v", current, desired, applied)
+		}
+		if len(applied) != len(desired) {
+			t.Fatalf("expected %d taints after applying the diff, but got: %v", len(desired), applied)
+		}
+		if !taints.DiffTaints(desired, desired).IsEmpty() {
+			t.Fatalf("expected no diff between %v and itself", desired)
+		}
+	}
+}
+
+func TestTaintsAreUnique(t *testing.T) {
+	g := NewGenerator(3)
+	for i := 0; i < 1000; i++ {
+		seen := map[v1.Taint]bool{}
+		for _, taint := range g.Taints(10) {
+			id := v1.Taint{Key: taint.Key, Effect: taint.Effect}
+			if seen[id] {
+				t.Fatalf("expected taints unique by key and effect, but got %v twice", id)
+			}
+			seen[id] = true
+		}
+	}
+}
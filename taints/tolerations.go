@@ -0,0 +1,158 @@
+package taints
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// anyKey is the key of a toleration spec tolerating taints with any key.
+const anyKey = "*"
+
+// lifecycleKeyPrefix is the prefix of the lifecycle taints tolerated by LifecycleTolerations.
+const lifecycleKeyPrefix = "node.kubernetes.io/"
+
+// TolerateAll returns the toleration of every taint, as used by critical addons and DaemonSets
+// that must run on every node regardless of its taints.
+func TolerateAll() []v1.Toleration {
+	return []v1.Toleration{{Operator: v1.TolerationOpExists}}
+}
+
+// LifecycleTolerations returns tolerations of the node.kubernetes.io/* lifecycle taints of the
+// well-known catalog, with each of their effects, such as those the DaemonSet controller adds to
+// DaemonSet pods. Pods with these tolerations keep running on nodes that are not ready, are
+// unreachable or are under resource pressure.
+func LifecycleTolerations() []v1.Toleration {
+	var tolerations []v1.Toleration
+	for _, taint := range wellKnownTaints {
+		if !taint.Lifecycle || !strings.HasPrefix(taint.Key, lifecycleKeyPrefix) {
+			continue
+		}
+		for _, effect := range taint.Effects {
+			tolerations = append(tolerations, v1.Toleration{
+				Key:      taint.Key,
+				Operator: v1.TolerationOpExists,
+				Effect:   effect,
+			})
+		}
+	}
+	return tolerations
+}
+
+// TaintFilter selects the taints to consider when matching tolerations.
+type TaintFilter func(taint *v1.Taint) bool
+
+// schedulingEffects selects the taints that keep pods from being scheduled.
+func schedulingEffects(taint *v1.Taint) bool {
+	return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+}
+
+// TolerationsTolerateTaint checks whether one of the tolerations tolerates the taint, with the
+// semantics of the scheduler: a toleration with an empty key and the Exists operator matches any
+// key, an empty effect matches any effect, Exists matches any value and Equal the same value.
+func TolerationsTolerateTaint(tolerations []v1.Toleration, taint *v1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// TolerationsTolerateTaints checks whether the tolerations tolerate every taint selected by the
+// filter. A nil filter selects every taint.
+func TolerationsTolerateTaints(tolerations []v1.Toleration, taints []v1.Taint, filter TaintFilter) bool {
+	_, untolerated := FindUntoleratedTaint(taints, tolerations, filter)
+	return !untolerated
+}
+
+// FindUntoleratedTaint returns the first taint selected by the filter that none of the
+// tolerations tolerates, and whether there is one. A nil filter selects every taint.
+func FindUntoleratedTaint(taints []v1.Taint, tolerations []v1.Toleration, filter TaintFilter) (v1.Taint, bool) {
+	for i := range taints {
+		if filter != nil && !filter(&taints[i]) {
+			continue
+		}
+		if !TolerationsTolerateTaint(tolerations, &taints[i]) {
+			return taints[i], true
+		}
+	}
+	return v1.Taint{}, false
+}
+
+// ParseTolerations parses toleration specs, whose form mirrors taint specs:
+//
+//   - '<key>=<value>[:<effect>]' tolerates taints with the key and value,
+//   - '<key>[:<effect>]' tolerates taints with the key and any value,
+//   - '*[:<effect>]' tolerates taints with any key,
+//
+// restricted to taints with the effect if one is given. Specs with the NoExecute effect may end
+// with ':<seconds>', the time for which the toleration keeps pods bound after the taint is added,
+// such as 'node.kubernetes.io/unreachable:NoExecute:300'.
+func ParseTolerations(spec []string) ([]v1.Toleration, error) {
+	o, err := newOptions(nil)
+	if err != nil {
+		return nil, err
+	}
+	tolerations := make([]v1.Toleration, 0, len(spec))
+	for _, tolerationSpec := range spec {
+		toleration, err := o.parseToleration(tolerationSpec)
+		if err != nil {
+			return nil, err
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations, nil
+}
+
+// parseToleration parses a toleration from a string in one of the forms of ParseTolerations.
+func (o *options) parseToleration(st string) (v1.Toleration, error) {
+	var toleration v1.Toleration
+
+	parts := strings.Split(st, ":")
+	if len(parts) > 3 {
+		return toleration, fmt.Errorf("invalid toleration spec: %v", st)
+	}
+	if len(parts) > 1 {
+		toleration.Effect = o.translateEffect(parts[1])
+		if err := o.validateTaintEffect(toleration.Effect); err != nil {
+			err.Spec = st
+			return toleration, err
+		}
+	}
+	if len(parts) == 3 {
+		if toleration.Effect != v1.TaintEffectNoExecute {
+			return toleration, fmt.Errorf("invalid toleration spec: %v, toleration seconds require the %v effect", st, v1.TaintEffectNoExecute)
+		}
+		seconds, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return toleration, fmt.Errorf("invalid toleration spec: %v, invalid toleration seconds: %v", st, parts[2])
+		}
+		toleration.TolerationSeconds = &seconds
+	}
+
+	key, value, hasValue := strings.Cut(parts[0], "=")
+	switch {
+	case key == anyKey && hasValue:
+		return toleration, fmt.Errorf("invalid toleration spec: %v, a value requires a key", st)
+	case key == anyKey:
+		toleration.Operator = v1.TolerationOpExists
+		return toleration, nil
+	case hasValue:
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return toleration, fmt.Errorf("invalid toleration spec: %v, %s", st, strings.Join(errs, "; "))
+		}
+		toleration.Operator = v1.TolerationOpEqual
+		toleration.Value = value
+	default:
+		toleration.Operator = v1.TolerationOpExists
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return toleration, fmt.Errorf("invalid toleration spec: %v, %s", st, strings.Join(errs, "; "))
+	}
+	toleration.Key = key
+	return toleration, nil
+}
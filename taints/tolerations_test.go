@@ -0,0 +1,141 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestTolerateAll(t *testing.T) {
+	taints := []v1.Taint{
+		{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		{Key: v1.TaintNodeNotReady, Effect: v1.TaintEffectNoExecute},
+	}
+	tolerations := TolerateAll()
+	for i := range taints {
+		if !tolerations[0].ToleratesTaint(&taints[i]) {
+			t.Errorf("expected taint %v to be tolerated", taints[i].ToString())
+		}
+	}
+}
+
+func TestLifecycleTolerations(t *testing.T) {
+	tolerations := LifecycleTolerations()
+	tolerated := func(taint v1.Taint) bool {
+		for i := range tolerations {
+			if tolerations[i].ToleratesTaint(&taint) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, taint := range []v1.Taint{
+		{Key: v1.TaintNodeNotReady, Effect: v1.TaintEffectNoExecute},
+		{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute},
+		{Key: v1.TaintNodeDiskPressure, Effect: v1.TaintEffectNoSchedule},
+		{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule},
+	} {
+		if !tolerated(taint) {
+			t.Errorf("expected taint %v to be tolerated", taint.ToString())
+		}
+	}
+	for _, taint := range []v1.Taint{
+		{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute},
+		{Key: "node.cloudprovider.kubernetes.io/uninitialized", Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+	} {
+		if tolerated(taint) {
+			t.Errorf("expected taint %v not to be tolerated", taint.ToString())
+		}
+	}
+}
+
+func TestParseTolerations(t *testing.T) {
+	seconds := int64(300)
+	cases := []struct {
+		name                string
+		spec                []string
+		expectedTolerations []v1.Toleration
+		expectedErr         bool
+	}{
+		{
+			name: "valid tolerations",
+			spec: []string{"dedicated=gpu:NoSchedule", "dedicated=gpu", "spot", "spot:PreferNoSchedule", "*", "*:NoExecute", "node.kubernetes.io/unreachable:NoExecute:300"},
+			expectedTolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu"},
+				{Key: "spot", Operator: v1.TolerationOpExists},
+				{Key: "spot", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectPreferNoSchedule},
+				{Operator: v1.TolerationOpExists},
+				{Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+				{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+			},
+		},
+		{name: "empty spec", spec: []string{""}, expectedErr: true},
+		{name: "invalid effect", spec: []string{"spot:Sometimes"}, expectedErr: true},
+		{name: "invalid value", spec: []string{"dedicated=-gpu:NoSchedule"}, expectedErr: true},
+		{name: "value without key", spec: []string{"*=gpu"}, expectedErr: true},
+		{name: "seconds without NoExecute", spec: []string{"spot:NoSchedule:300"}, expectedErr: true},
+		{name: "invalid seconds", spec: []string{"spot:NoExecute:5m"}, expectedErr: true},
+		{name: "too many separators", spec: []string{"spot:NoExecute:300:1"}, expectedErr: true},
+	}
+
+	for _, c := range cases {
+		tolerations, err := ParseTolerations(c.spec)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for spec %s, but got nothing", c.name, c.spec)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for spec %s, but got: %v", c.name, c.spec, err)
+		}
+		if !reflect.DeepEqual(c.expectedTolerations, tolerations) {
+			t.Errorf("[%s] expected tolerations %+v, but got: %+v", c.name, c.expectedTolerations, tolerations)
+		}
+	}
+}
+
+func TestFindUntoleratedTaint(t *testing.T) {
+	gpu := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	spot := v1.Taint{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}
+	unreachable := v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}
+	taints := []v1.Taint{spot, gpu, unreachable}
+
+	cases := []struct {
+		name        string
+		tolerations []string
+		filter      TaintFilter
+		expected    *v1.Taint
+	}{
+		{name: "no tolerations", expected: &spot},
+		{name: "no tolerations, scheduling taints", filter: schedulingEffects, expected: &gpu},
+		{name: "value mismatch", tolerations: []string{"dedicated=cpu", "spot"}, expected: &gpu},
+		{name: "effect mismatch", tolerations: []string{"dedicated:NoExecute", "spot"}, expected: &gpu},
+		{name: "key tolerated with any effect", tolerations: []string{"dedicated", "spot"}, expected: &unreachable},
+		{name: "any key with an effect", tolerations: []string{"*:NoExecute", "dedicated=gpu:NoSchedule"}, filter: schedulingEffects},
+		{name: "any key", tolerations: []string{"*"}},
+	}
+
+	for _, c := range cases {
+		tolerations, err := ParseTolerations(c.tolerations)
+		if err != nil {
+			t.Fatalf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		taint, untolerated := FindUntoleratedTaint(taints, tolerations, c.filter)
+		if c.expected == nil {
+			if untolerated {
+				t.Errorf("[%s] expected every taint to be tolerated, but got: %v", c.name, taint.ToString())
+			}
+		} else if !untolerated || taint != *c.expected {
+			t.Errorf("[%s] expected untolerated taint %v, but got: %v", c.name, c.expected.ToString(), taint.ToString())
+		}
+		if tolerated := TolerationsTolerateTaints(tolerations, taints, c.filter); tolerated != (c.expected == nil) {
+			t.Errorf("[%s] expected TolerationsTolerateTaints to return %v, but got: %v", c.name, c.expected == nil, tolerated)
+		}
+	}
+
+	if !TolerationsTolerateTaint([]v1.Toleration{{Key: "spot", Operator: v1.TolerationOpExists}}, &spot) {
+		t.Errorf("expected taint %v to be tolerated", spot.ToString())
+	}
+}
@@ -0,0 +1,49 @@
+package taints
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// TransitionStage is a step of an effect transition.
+type TransitionStage struct {
+	// Diff holds the changes of the stage, to be applied to the taints left by the previous stage.
+	Diff TaintDiff `json:"diff"`
+	// Dwell is how long to wait after applying the stage before applying the next one.
+	Dwell time.Duration `json:"dwell,omitempty"`
+}
+
+// PlanEffectTransition returns the stages moving the taint with the key from one effect to
+// another, such as from NoSchedule to NoExecute to drain a node gradually. Rather than swapping
+// the effects at once, the taint is first added with the new effect and kept for the dwell time,
+// then removed with the old effect, so that pods are never left without either taint. It is an
+// error for the node not to have the taint with the old effect.
+func PlanEffectTransition(current []v1.Taint, key string, from, to v1.TaintEffect, dwell time.Duration) ([]TransitionStage, error) {
+	old := v1.Taint{Key: key, Effect: from}
+	i := indexOfTaint(current, &old)
+	if i < 0 {
+		return nil, fmt.Errorf("taint %v not found", old.ToString())
+	}
+	if from == to {
+		return nil, nil
+	}
+
+	var stages []TransitionStage
+	target := v1.Taint{Key: key, Value: current[i].Value, Effect: to}
+	if indexOfTaint(current, &target) < 0 {
+		added := append(append([]v1.Taint(nil), current...), target)
+		stages = append(stages, TransitionStage{Diff: DiffTaints(current, added), Dwell: dwell})
+		current = added
+	}
+
+	var desired []v1.Taint
+	for _, taint := range current {
+		if !taint.MatchTaint(&old) {
+			desired = append(desired, taint)
+		}
+	}
+	stages = append(stages, TransitionStage{Diff: DiffTaints(current, desired)})
+	return stages, nil
+}
@@ -0,0 +1,74 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPlanEffectTransition(t *testing.T) {
+	noSchedule := v1.Taint{Key: "example.com/drain", Value: "true", Effect: v1.TaintEffectNoSchedule}
+	noExecute := v1.Taint{Key: "example.com/drain", Value: "true", Effect: v1.TaintEffectNoExecute}
+	unrelated := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+
+	cases := []struct {
+		name        string
+		current     []v1.Taint
+		from, to    v1.TaintEffect
+		expected    [][]v1.Taint
+		expectedErr bool
+	}{
+		{
+			name:    "staged transition",
+			current: []v1.Taint{unrelated, noSchedule},
+			from:    v1.TaintEffectNoSchedule,
+			to:      v1.TaintEffectNoExecute,
+			expected: [][]v1.Taint{
+				{unrelated, noSchedule, noExecute},
+				{unrelated, noExecute},
+			},
+		},
+		{
+			name:     "new effect already present",
+			current:  []v1.Taint{noSchedule, noExecute},
+			from:     v1.TaintEffectNoSchedule,
+			to:       v1.TaintEffectNoExecute,
+			expected: [][]v1.Taint{{noExecute}},
+		},
+		{
+			name:    "same effect",
+			current: []v1.Taint{noSchedule},
+			from:    v1.TaintEffectNoSchedule,
+			to:      v1.TaintEffectNoSchedule,
+		},
+		{
+			name:        "taint not found",
+			current:     []v1.Taint{unrelated},
+			from:        v1.TaintEffectNoSchedule,
+			to:          v1.TaintEffectNoExecute,
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		stages, err := PlanEffectTransition(c.current, "example.com/drain", c.from, c.to, time.Minute)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		var desired [][]v1.Taint
+		for _, stage := range stages {
+			desired = append(desired, stage.Diff.Desired())
+		}
+		if len(stages) > 1 && stages[0].Dwell != time.Minute {
+			t.Errorf("[%s] expected a dwell time of 1m0s after adding the new effect, but got: %v", c.name, stages[0].Dwell)
+		}
+		if !reflect.DeepEqual(c.expected, desired) {
+			t.Errorf("[%s] expected stages leading to taints %v, but got: %v", c.name, c.expected, desired)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// SpecResult is the validation outcome of a single spec.
+type SpecResult struct {
+	// Index is the position of the spec in the validated list.
+	Index int    `json:"index"`
+	Spec  string `json:"spec"`
+	Valid bool   `json:"valid"`
+	// Taint is the parsed taint, or nil if the spec does not parse.
+	Taint *v1.Taint `json:"taint,omitempty"`
+	// Remove reports whether the spec removes the taint rather than adding it.
+	Remove bool `json:"remove,omitempty"`
+	// Error describes why the spec is invalid.
+	Error string `json:"error,omitempty"`
+	// Warnings describe legal but likely unintended forms, as reported by Lint.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ValidateTaintSpecsDetailed validates each spec and returns a result per spec, in the order of
+// the specs, instead of stopping at the first invalid one. A spec that parses on its own can
+// still be invalid in the context of the list, such as a duplicated taint.
+func ValidateTaintSpecsDetailed(spec []string) []SpecResult {
+	results := make([]SpecResult, len(spec))
+	for i, taintSpec := range spec {
+		results[i] = SpecResult{Index: i, Spec: taintSpec, Valid: true}
+		taints, taintsToRemove, err := ParseTaints([]string{taintSpec})
+		switch {
+		case err != nil:
+		case len(taints) > 0:
+			results[i].Taint = &taints[0]
+		default:
+			results[i].Taint = &taintsToRemove[0]
+			results[i].Remove = true
+		}
+	}
+
+	for _, finding := range Lint(spec) {
+		result := &results[finding.Index]
+		if finding.Severity == SeverityError {
+			if result.Valid {
+				result.Valid = false
+				result.Error = finding.Message
+			}
+			continue
+		}
+		result.Warnings = append(result.Warnings, finding.Message)
+	}
+	return results
+}
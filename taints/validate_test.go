@@ -0,0 +1,56 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestValidateTaintSpecsDetailed(t *testing.T) {
+	spec := []string{
+		"example.com/foo=abc:NoSchedule",
+		"example.com/bar",
+		"example.com/baz=:NoExecute",
+		"example.com/foo=xyz:NoSchedule",
+		"example.com/qux:NoSchedule-",
+	}
+	expected := []SpecResult{
+		{
+			Index: 0,
+			Spec:  spec[0],
+			Valid: true,
+			Taint: &v1.Taint{Key: "example.com/foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+		},
+		{
+			Index: 1,
+			Spec:  spec[1],
+			Error: "invalid taint spec: example.com/bar",
+		},
+		{
+			Index:    2,
+			Spec:     spec[2],
+			Valid:    true,
+			Taint:    &v1.Taint{Key: "example.com/baz", Effect: v1.TaintEffectNoExecute},
+			Warnings: []string{"empty value, write example.com/baz:NoExecute instead"},
+		},
+		{
+			Index: 3,
+			Spec:  spec[3],
+			Taint: &v1.Taint{Key: "example.com/foo", Value: "xyz", Effect: v1.TaintEffectNoSchedule},
+			Error: "duplicated taints with the same key and effect: example.com/foo=xyz:NoSchedule",
+		},
+		{
+			Index:  4,
+			Spec:   spec[4],
+			Valid:  true,
+			Taint:  &v1.Taint{Key: "example.com/qux", Effect: v1.TaintEffectNoSchedule},
+			Remove: true,
+		},
+	}
+
+	results := ValidateTaintSpecsDetailed(spec)
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("expected results %+v, but got: %+v", expected, results)
+	}
+}
@@ -0,0 +1,49 @@
+package taints
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeGetter retrieves nodes by name. It is satisfied by the node client of a client-go
+// clientset, i.e. clientset.CoreV1().Nodes().
+type NodeGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Node, error)
+}
+
+// VerifyRemovals fetches the named node and returns the removals, as returned by ParseTaints,
+// that match none of its taints. Removing such a taint would silently do nothing, which kubectl
+// reports as "taint not found".
+func VerifyRemovals(ctx context.Context, client NodeGetter, nodeName string, removals []v1.Taint) ([]v1.Taint, error) {
+	node, err := client.Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return missingRemovals(node.Spec.Taints, removals), nil
+}
+
+// removalMatches reports whether the removal applies to the taint. A removal without an effect
+// matches any taint with the same key.
+func removalMatches(removal *v1.Taint, taint *v1.Taint) bool {
+	return removal.Key == taint.Key && (len(removal.Effect) == 0 || removal.Effect == taint.Effect)
+}
+
+// missingRemovals returns the removals that match none of the taints.
+func missingRemovals(taints []v1.Taint, removals []v1.Taint) []v1.Taint {
+	var missing []v1.Taint
+	for _, removal := range removals {
+		found := false
+		for i := range taints {
+			if removalMatches(&removal, &taints[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, removal)
+		}
+	}
+	return missing
+}
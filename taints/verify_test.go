@@ -0,0 +1,79 @@
+package taints
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeNodeGetter map[string]*v1.Node
+
+func (f fakeNodeGetter) Get(_ context.Context, name string, _ metav1.GetOptions) (*v1.Node, error) {
+	node, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("nodes %q not found", name)
+	}
+	return node, nil
+}
+
+func TestVerifyRemovals(t *testing.T) {
+	client := fakeNodeGetter{
+		"worker-1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Spec: v1.NodeSpec{
+				Taints: []v1.Taint{
+					{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+					{Key: "bar", Effect: v1.TaintEffectNoExecute},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name            string
+		nodeName        string
+		removals        []v1.Taint
+		expectedMissing []v1.Taint
+		expectedErr     bool
+	}{
+		{
+			name:        "unknown node",
+			nodeName:    "worker-2",
+			removals:    []v1.Taint{{Key: "foo"}},
+			expectedErr: true,
+		},
+		{
+			name:     "removals matching by key and effect",
+			nodeName: "worker-1",
+			removals: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoSchedule}, {Key: "bar", Effect: v1.TaintEffectNoExecute}},
+		},
+		{
+			name:     "removal without effect matches any effect",
+			nodeName: "worker-1",
+			removals: []v1.Taint{{Key: "bar"}},
+		},
+		{
+			name:            "removals matching nothing",
+			nodeName:        "worker-1",
+			removals:        []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoExecute}, {Key: "bar"}, {Key: "baz"}},
+			expectedMissing: []v1.Taint{{Key: "foo", Effect: v1.TaintEffectNoExecute}, {Key: "baz"}},
+		},
+	}
+
+	for _, c := range cases {
+		missing, err := VerifyRemovals(context.Background(), client, c.nodeName, c.removals)
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error, but got nothing", c.name)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error, but got: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.expectedMissing, missing) {
+			t.Errorf("[%s] expected missing removals %v, but got: %v", c.name, c.expectedMissing, missing)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package taints
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// validationProfile captures the taint validation rules of a range of Kubernetes releases.
+type validationProfile struct {
+	// since is the first release the profile applies to.
+	since *version.Version
+	// effects are the taint effects accepted by the API server.
+	effects sets.Set[v1.TaintEffect]
+}
+
+// validationProfiles lists the known profiles, oldest first. Taints were introduced in 1.4 as an
+// alpha node annotation supporting NoSchedule and PreferNoSchedule, and became the spec.taints
+// field with the additional NoExecute effect in 1.6. Key and value constraints have not changed
+// since.
+var validationProfiles = []validationProfile{
+	{
+		since:   version.MajorMinor(1, 4),
+		effects: sets.New(v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule),
+	},
+	{
+		since:   version.MajorMinor(1, 6),
+		effects: sets.New(v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute),
+	},
+}
+
+// WithTargetVersion validates specs against the rules of the given Kubernetes version, such as
+// "1.29" or "v1.29.3". Without it, specs are validated against the latest known rules.
+func WithTargetVersion(v string) Option {
+	return func(o *options) error {
+		target, err := version.ParseGeneric(v)
+		if err != nil {
+			return fmt.Errorf("invalid target version: %v", err)
+		}
+		for i := len(validationProfiles) - 1; i >= 0; i-- {
+			if target.AtLeast(validationProfiles[i].since) {
+				o.profile = validationProfiles[i]
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid target version: %v, taints are not supported before %v", v, validationProfiles[0].since)
+	}
+}
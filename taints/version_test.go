@@ -0,0 +1,74 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWithTargetVersion(t *testing.T) {
+	cases := []struct {
+		name           string
+		version        string
+		spec           []string
+		expectedTaints []v1.Taint
+		expectedErr    bool
+	}{
+		{
+			name:        "invalid version",
+			version:     "latest",
+			spec:        []string{"foo=abc:NoSchedule"},
+			expectedErr: true,
+		},
+		{
+			name:        "version predating taints",
+			version:     "1.3",
+			spec:        []string{"foo=abc:NoSchedule"},
+			expectedErr: true,
+		},
+		{
+			name:        "NoExecute is rejected before 1.6",
+			version:     "1.5",
+			spec:        []string{"foo=abc:NoExecute"},
+			expectedErr: true,
+		},
+		{
+			name:    "NoSchedule is accepted before 1.6",
+			version: "1.5",
+			spec:    []string{"foo=abc:NoSchedule"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:    "NoExecute is accepted from 1.6",
+			version: "1.6",
+			spec:    []string{"foo=abc:NoExecute"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		{
+			name:    "patch version with leading v and build suffix",
+			version: "v1.29.3-gke.100",
+			spec:    []string{"foo=abc:NoExecute"},
+			expectedTaints: []v1.Taint{
+				{Key: "foo", Value: "abc", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		taints, _, err := ParseTaintsWithOptions(c.spec, WithTargetVersion(c.version))
+		if c.expectedErr && err == nil {
+			t.Errorf("[%s] expected error for version %s, but got nothing", c.name, c.version)
+		}
+		if !c.expectedErr && err != nil {
+			t.Errorf("[%s] expected no error for version %s, but got: %v", c.name, c.version, err)
+		}
+		if !reflect.DeepEqual(c.expectedTaints, taints) {
+			t.Errorf("[%s] expected taints %v, but got: %v", c.name, c.expectedTaints, taints)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package taints
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// WellKnownTaint describes a taint key used by Kubernetes components.
+type WellKnownTaint struct {
+	Key string `json:"key"`
+	// Effects are the effects the taint is used with.
+	Effects []v1.TaintEffect `json:"effects"`
+	// ManagedBy names the component adding and removing the taint.
+	ManagedBy string `json:"managedBy"`
+	// Lifecycle marks taints that reflect the state of the node, which components add and remove
+	// automatically as the node goes through its lifecycle.
+	Lifecycle bool `json:"lifecycle"`
+}
+
+var (
+	noSchedule             = []v1.TaintEffect{v1.TaintEffectNoSchedule}
+	noExecute              = []v1.TaintEffect{v1.TaintEffectNoExecute}
+	noScheduleAndNoExecute = []v1.TaintEffect{v1.TaintEffectNoSchedule, v1.TaintEffectNoExecute}
+)
+
+// wellKnownTaints is the catalog returned by WellKnownTaints.
+var wellKnownTaints = []WellKnownTaint{
+	{Key: v1.TaintNodeNotReady, Effects: noScheduleAndNoExecute, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeUnreachable, Effects: noScheduleAndNoExecute, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeUnschedulable, Effects: noSchedule, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeMemoryPressure, Effects: noSchedule, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeDiskPressure, Effects: noSchedule, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeNetworkUnavailable, Effects: noSchedule, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodePIDPressure, Effects: noSchedule, ManagedBy: "node-lifecycle-controller", Lifecycle: true},
+	{Key: v1.TaintNodeOutOfService, Effects: noScheduleAndNoExecute, ManagedBy: "cluster administrator"},
+	{Key: "node.cloudprovider.kubernetes.io/uninitialized", Effects: noSchedule, ManagedBy: "cloud-controller-manager", Lifecycle: true},
+	{Key: "node.cloudprovider.kubernetes.io/shutdown", Effects: noSchedule, ManagedBy: "cloud-controller-manager", Lifecycle: true},
+	{Key: "node-role.kubernetes.io/control-plane", Effects: noSchedule, ManagedBy: "kubeadm"},
+	{Key: "node-role.kubernetes.io/master", Effects: noSchedule, ManagedBy: "kubeadm"},
+	{Key: autoscalerToBeDeletedTaint, Effects: noSchedule, ManagedBy: "cluster-autoscaler", Lifecycle: true},
+	{Key: autoscalerDeletionCandidateTaint, Effects: []v1.TaintEffect{v1.TaintEffectPreferNoSchedule}, ManagedBy: "cluster-autoscaler", Lifecycle: true},
+}
+
+// WellKnownTaints returns the catalog of taint keys used by Kubernetes components.
+func WellKnownTaints() []WellKnownTaint {
+	return append([]WellKnownTaint(nil), wellKnownTaints...)
+}
+
+// LookupWellKnownTaint returns the catalog entry of the key, or false if it is not well known.
+func LookupWellKnownTaint(key string) (WellKnownTaint, bool) {
+	for _, taint := range wellKnownTaints {
+		if taint.Key == key {
+			return taint, true
+		}
+	}
+	return WellKnownTaint{}, false
+}
+
+// WithKnownKeys reports a warning for every removal spec whose key is not one of the keys, not a
+// well-known taint key and not added by the spec itself, which usually is a typo such as
+// 'dedicted-' that removes nothing. The warning suggests the closest of these keys.
+func WithKnownKeys(keys ...string) Option {
+	return func(o *options) error {
+		if o.knownKeys == nil {
+			o.knownKeys = sets.New[string]()
+		}
+		o.knownKeys.Insert(keys...)
+		return nil
+	}
+}
+
+// warnUnknownRemovals warns about removals of keys unknown to WithKnownKeys.
+func (o *options) warnUnknownRemovals(taints []v1.Taint, taintsToRemove []TaintRemoval) {
+	if o.knownKeys == nil {
+		return
+	}
+	added := sets.New[string]()
+	for _, taint := range taints {
+		added.Insert(taint.Key)
+	}
+	for _, removal := range taintsToRemove {
+		if o.knownKeys.Has(removal.Key) || added.Has(removal.Key) {
+			continue
+		}
+		if _, ok := LookupWellKnownTaint(removal.Key); ok {
+			continue
+		}
+		if suggestion := o.suggestKey(removal.Key, added); len(suggestion) > 0 {
+			o.warnf("removal of unknown taint key: %v, did you mean %v?", removal.Key, suggestion)
+			continue
+		}
+		o.warnf("removal of unknown taint key: %v", removal.Key)
+	}
+}
@@ -0,0 +1,44 @@
+package taints
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestLookupWellKnownTaint(t *testing.T) {
+	if taint, ok := LookupWellKnownTaint(v1.TaintNodeUnreachable); !ok || !taint.Lifecycle {
+		t.Errorf("expected %v to be a well-known lifecycle taint, but got: %+v", v1.TaintNodeUnreachable, taint)
+	}
+	if taint, ok := LookupWellKnownTaint("example.com/custom"); ok {
+		t.Errorf("expected example.com/custom not to be well known, but got: %+v", taint)
+	}
+
+	catalog := WellKnownTaints()
+	catalog[0].Key = "changed"
+	if WellKnownTaints()[0].Key == "changed" {
+		t.Errorf("expected WellKnownTaints to return a copy of the catalog")
+	}
+}
+
+func TestWithKnownKeys(t *testing.T) {
+	spec := []string{"dedicated=gpu:NoSchedule", "dedicated:NoExecute-", "dedicted-", "spot-", "node.kubernetes.io/unschedulable:NoSchedule-"}
+
+	result, err := Parse(spec, WithKnownKeys("spot"))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expected := []string{"removal of unknown taint key: dedicted, did you mean dedicated?"}
+	if !reflect.DeepEqual(expected, result.Warnings) {
+		t.Errorf("expected warnings %v, but got: %v", expected, result.Warnings)
+	}
+
+	result, err = Parse(spec)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	if len(result.Warnings) > 0 {
+		t.Errorf("expected no warnings without known keys, but got: %v", result.Warnings)
+	}
+}
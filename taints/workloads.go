@@ -0,0 +1,99 @@
+package taints
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podSpecTolerations holds the tolerations of a pod spec.
+type podSpecTolerations struct {
+	Tolerations []v1.Toleration `json:"tolerations"`
+}
+
+// podTemplateTolerations holds the tolerations of a pod template.
+type podTemplateTolerations struct {
+	Spec podSpecTolerations `json:"spec"`
+}
+
+// workloadManifest holds the parts of a pod or workload manifest holding tolerations.
+type workloadManifest struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		// Tolerations are those of a Pod.
+		Tolerations []v1.Toleration `json:"tolerations"`
+		// Template is the pod template of a Deployment, StatefulSet, DaemonSet, ReplicaSet or Job.
+		Template podTemplateTolerations `json:"template"`
+		// JobTemplate is the job template of a CronJob.
+		JobTemplate struct {
+			Spec struct {
+				Template podTemplateTolerations `json:"template"`
+			} `json:"spec"`
+		} `json:"jobTemplate"`
+	} `json:"spec"`
+}
+
+// ReadWorkloadsFromManifest reads YAML or JSON manifests and returns a Workload per Pod,
+// Deployment, StatefulSet, DaemonSet, ReplicaSet, Job and CronJob they contain, with the
+// tolerations of its pods. Workloads are named '<kind>/<namespace>/<name>', or '<kind>/<name>'
+// if the manifest has no namespace. Manifests of other kinds are ignored.
+func ReadWorkloadsFromManifest(r io.Reader) ([]Workload, error) {
+	var workloads []Workload
+	err := walkManifest(r, func(kind string, raw json.RawMessage) error {
+		switch kind {
+		case "Pod", "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "CronJob":
+		default:
+			return nil
+		}
+		var manifest workloadManifest
+		if err := json.Unmarshal(raw, &manifest); err != nil {
+			return fmt.Errorf("invalid %v manifest: %v", kind, err)
+		}
+
+		workload := Workload{Name: kind + "/" + manifest.Name}
+		if len(manifest.Namespace) > 0 {
+			workload.Name = kind + "/" + manifest.Namespace + "/" + manifest.Name
+		}
+		switch kind {
+		case "Pod":
+			workload.Tolerations = manifest.Spec.Tolerations
+		case "CronJob":
+			workload.Tolerations = manifest.Spec.JobTemplate.Spec.Template.Spec.Tolerations
+		default:
+			workload.Tolerations = manifest.Spec.Template.Spec.Tolerations
+		}
+		workloads = append(workloads, workload)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workloads, nil
+}
+
+// WorkloadCheck is the outcome of checking whether a workload can be scheduled on tainted nodes.
+type WorkloadCheck struct {
+	Workload    string `json:"workload"`
+	Schedulable bool   `json:"schedulable"`
+	// UntoleratedTaint is the first taint keeping the workload off the nodes.
+	UntoleratedTaint *v1.Taint `json:"untoleratedTaint,omitempty"`
+}
+
+// CheckWorkloads reports for each workload whether its pods can be scheduled on nodes with the
+// taints, such as the taints to be added returned by ParseTaints. Only NoSchedule and NoExecute
+// taints keep pods from being scheduled.
+func CheckWorkloads(workloads []Workload, taints []v1.Taint) []WorkloadCheck {
+	checks := make([]WorkloadCheck, 0, len(workloads))
+	for _, workload := range workloads {
+		check := WorkloadCheck{Workload: workload.Name, Schedulable: true}
+		if taint, untolerated := FindUntoleratedTaint(taints, workload.Tolerations, schedulingEffects); untolerated {
+			check.Schedulable = false
+			check.UntoleratedTaint = &taint
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
@@ -0,0 +1,92 @@
+package taints
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const testWorkloadsManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: trainer
+  namespace: ml
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: dedicated
+        operator: Equal
+        value: gpu
+        effect: NoSchedule
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  tolerations:
+  - operator: Exists
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers: []
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: ignored
+`
+
+func TestReadWorkloadsFromManifest(t *testing.T) {
+	workloads, err := ReadWorkloadsFromManifest(strings.NewReader(testWorkloadsManifest))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	expected := []Workload{
+		{
+			Name:        "Deployment/ml/trainer",
+			Tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+		},
+		{
+			Name:        "Pod/debug",
+			Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}},
+		},
+		{
+			Name: "CronJob/report",
+		},
+	}
+	if !reflect.DeepEqual(expected, workloads) {
+		t.Errorf("expected workloads %+v, but got: %+v", expected, workloads)
+	}
+}
+
+func TestCheckWorkloads(t *testing.T) {
+	workloads, err := ReadWorkloadsFromManifest(strings.NewReader(testWorkloadsManifest))
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+	taints, _, err := ParseTaints([]string{"dedicated=gpu:NoSchedule", "spot:PreferNoSchedule"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %v", err)
+	}
+
+	expected := []WorkloadCheck{
+		{Workload: "Deployment/ml/trainer", Schedulable: true},
+		{Workload: "Pod/debug", Schedulable: true},
+		{Workload: "CronJob/report", UntoleratedTaint: &v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+	}
+	if checks := CheckWorkloads(workloads, taints); !reflect.DeepEqual(expected, checks) {
+		t.Errorf("expected checks %+v, but got: %+v", expected, checks)
+	}
+}